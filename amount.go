@@ -0,0 +1,77 @@
+package mon
+
+import (
+	"math"
+	"math/bits"
+)
+
+// amount is a non-negative (possibly fractional) amount of a task's unit.
+//
+// The whole part is stored separately from the fraction so that whole steps
+// (see [Task.CompleteSteps]) are counted exactly across the full range of
+// uint64, whilst fractional amounts (see [Task.CompleteAmount]) are still
+// supported.
+type amount struct {
+	whole    uint64
+	fraction float64 // in [0, 1)
+}
+
+// amountOf returns the amount for the given value. Values that are not
+// positive (including NaN) are zero, and values that are too large are
+// clamped to the largest amount.
+func amountOf(value float64) amount {
+	switch {
+	case !(value > 0):
+		return amount{}
+	case value >= math.MaxUint64:
+		return amount{whole: math.MaxUint64}
+	}
+
+	whole := uint64(value)
+	return amount{whole: whole, fraction: value - float64(whole)}
+}
+
+// steps returns the amount for the given number of whole steps.
+func steps(n uint64) amount {
+	return amount{whole: n}
+}
+
+func (a amount) float() float64 {
+	return float64(a.whole) + a.fraction
+}
+
+func (a amount) isZero() bool {
+	return a.whole == 0 && a.fraction == 0
+}
+
+func (a amount) less(b amount) bool {
+	return a.whole < b.whole || (a.whole == b.whole && a.fraction < b.fraction)
+}
+
+// add returns the sum of the amounts, clamped to the largest amount.
+func (a amount) add(b amount) amount {
+	whole, carry := bits.Add64(a.whole, b.whole, 0)
+	fraction := a.fraction + b.fraction
+	if fraction >= 1 {
+		var fractionCarry uint64
+		whole, fractionCarry = bits.Add64(whole, 1, 0)
+		carry += fractionCarry
+		fraction--
+	}
+
+	if carry != 0 {
+		return amount{whole: math.MaxUint64}
+	}
+
+	return amount{whole: whole, fraction: fraction}
+}
+
+// sub returns the difference between the amounts (which is zero if b is not
+// less than a), as a float.
+func (a amount) sub(b amount) float64 {
+	if !b.less(a) {
+		return 0
+	}
+
+	return float64(a.whole-b.whole) + a.fraction - b.fraction
+}
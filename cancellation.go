@@ -0,0 +1,262 @@
+package mon
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// blockedHintDuration is how long the hint that cancellation is blocked is
+// displayed after the user attempts to cancel.
+const blockedHintDuration = 3 * time.Second
+
+// forceQuitExitCode is the exit code used when the user forces the process to
+// exit (the conventional exit code for a process terminated by SIGINT).
+const forceQuitExitCode = 130
+
+// Causes given to the context used by the monitor (see [context.Cause]) when
+// it is cancelled by the monitor.
+var (
+	// ErrUserInterrupted is the cause when the user presses Ctrl+C in the
+	// monitor.
+	ErrUserInterrupted = errors.New("mon: interrupted by user")
+
+	// ErrMonitorClosed is the cause when the cancel function returned by
+	// [M.Show] is called with a nil cause.
+	ErrMonitorClosed = errors.New("mon: monitor closed")
+
+	// ErrInterruptSignal is the cause when the process receives SIGINT (for
+	// example, when the user presses Ctrl+C and the terminal is not in raw
+	// mode).
+	ErrInterruptSignal = errors.New("mon: received interrupt signal")
+
+	// ErrTerminateSignal is the cause when the process receives SIGTERM.
+	ErrTerminateSignal = errors.New("mon: received terminate signal")
+
+	// ErrHangupSignal is the cause when the process receives SIGHUP (for
+	// example, when the terminal is closed).
+	ErrHangupSignal = errors.New("mon: received hangup signal")
+
+	// ErrTaskCancelled is the cause given to a task's context (see
+	// [Task.Context]) when the user cancels the task in the monitor.
+	ErrTaskCancelled = errors.New("mon: task cancelled by user")
+)
+
+// signalCauses maps the signals handled by the monitor to the cause given to
+// the monitor's context.
+var signalCauses = map[os.Signal]error{
+	syscall.SIGINT:  ErrInterruptSignal,
+	syscall.SIGTERM: ErrTerminateSignal,
+	syscall.SIGHUP:  ErrHangupSignal,
+}
+
+// signalMsg is sent to the program when the process receives a signal.
+type signalMsg struct {
+	signal os.Signal
+}
+
+// handleSignals relays the signals in signalCauses to the program until the
+// returned function is called.
+//
+// This replaces bubbletea's signal handler so that each signal can be mapped
+// to a distinct cause.
+func (m *model) handleSignals() (stop func()) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, slices.Collect(maps.Keys(signalCauses))...)
+
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case sig := <-signals:
+				m.prog.Send(signalMsg{signal: sig})
+			}
+		}
+	}()
+
+	return func() {
+		signal.Stop(signals)
+		close(done)
+	}
+}
+
+// handleSignal handles the process receiving a signal. SIGINT is treated in
+// the same way as the user pressing Ctrl+C, whereas other signals always quit
+// the monitor.
+func (m *model) handleSignal(sig os.Signal) tea.Cmd {
+	cause := signalCauses[sig]
+	if sig == syscall.SIGINT {
+		return m.handleInterrupt(cause)
+	}
+
+	m.done = true
+	m.cause = cause
+	return tea.Quit
+}
+
+// deferredInterruptMsg re-applies an interrupt that was deferred whilst
+// cancellation was blocked by a scope.
+type deferredInterruptMsg struct {
+	cause error
+}
+
+// cancellationScope is a block on cancellation created with
+// [M.BlockCancellationScope].
+type cancellationScope struct {
+	reason string
+}
+
+func (m *model) BlockCancellationScope(reason string) func() {
+	scope := &cancellationScope{reason: reason}
+
+	m.scopesMutex.Lock()
+	m.scopes = append(m.scopes, scope)
+	m.scopesMutex.Unlock()
+	m.notify()
+
+	var release sync.Once
+	return func() {
+		release.Do(func() { m.releaseScope(scope) })
+	}
+}
+
+// releaseScope removes the given scope and, if it was the last scope and the
+// user attempted to cancel whilst it was active, applies the interrupt.
+func (m *model) releaseScope(scope *cancellationScope) {
+	m.scopesMutex.Lock()
+	m.scopes = slices.DeleteFunc(m.scopes, func(s *cancellationScope) bool { return s == scope })
+	deferred := len(m.scopes) == 0 && m.deferredInterrupt != nil
+	cause := m.deferredInterrupt
+	if deferred {
+		m.deferredInterrupt = nil
+	}
+	m.scopesMutex.Unlock()
+
+	if deferred && m.prog != nil {
+		m.prog.Send(deferredInterruptMsg{cause: cause})
+		return
+	}
+
+	m.notify()
+}
+
+// blockReasons returns the reasons given for each active cancellation scope.
+func (m *model) blockReasons() []string {
+	m.scopesMutex.Lock()
+	defer m.scopesMutex.Unlock()
+
+	reasons := make([]string, len(m.scopes))
+	for i, scope := range m.scopes {
+		reasons[i] = scope.reason
+	}
+
+	return reasons
+}
+
+// handleInterrupt handles the user pressing Ctrl+C (or an equivalent
+// interrupt). If the interrupt cancels the monitor's context, the given cause
+// is used.
+func (m *model) handleInterrupt(cause error) tea.Cmd {
+	if m.blockCancellation {
+		m.blockedAt = time.Now()
+		return nil
+	}
+
+	// Whilst a scope is active, the interrupt is deferred until the last
+	// scope is released.
+	m.scopesMutex.Lock()
+	if len(m.scopes) > 0 {
+		m.deferredInterrupt = cause
+		m.scopesMutex.Unlock()
+		return nil
+	}
+	m.scopesMutex.Unlock()
+
+	m.blockedAt = time.Time{}
+
+	if !m.confirmCancellation {
+		m.done = true
+		m.cause = cause
+		return tea.Quit
+	}
+
+	m.interrupts++
+	switch m.interrupts {
+	case 1:
+		// Wait for the user to confirm.
+		return nil
+	case 2:
+		if m.cancel != nil {
+			m.cancel(cause)
+		}
+		return nil
+	default:
+		m.done = true
+		m.cause = cause
+		m.forceQuit = true
+		return tea.Quit
+	}
+}
+
+// exitIfForced exits the process if the user forced the monitor to quit and
+// [M.ExitOnForceQuit] is set. This must be called once the bubbletea program
+// has exited (and restored the terminal).
+func (m *model) exitIfForced() {
+	if m.forceQuit && m.exitOnForceQuit {
+		os.Exit(forceQuitExitCode)
+	}
+}
+
+// renderCancellation renders the banner describing the state of cancellation,
+// or an empty string if there is nothing to display.
+func (m *model) renderCancellation(tasks []Task) string {
+	var banner string
+
+	m.scopesMutex.Lock()
+	deferred := m.deferredInterrupt != nil
+	m.scopesMutex.Unlock()
+
+	switch {
+	case deferred:
+		banner = "Cancelling once finished: " + strings.Join(m.blockReasons(), ", ")
+	case !m.blockedAt.IsZero() && time.Since(m.blockedAt) < blockedHintDuration:
+		banner = "Cannot cancel now, please wait"
+	case m.interrupts == 1:
+		running := 0
+		for _, t := range tasks {
+			if !t.IsCompleted() {
+				running++
+			}
+		}
+
+		banner = fmt.Sprintf("Press Ctrl+C again to abort (%d tasks running)", running)
+	case m.interrupts > 1:
+		banner = "Aborting, press Ctrl+C again to force quit"
+	default:
+		return ""
+	}
+
+	return warningStyle.Render(banner) + "\n"
+}
+
+// renderBlockReasons renders the reasons that cancellation is currently
+// blocked by a scope, or an empty string if it is not.
+func (m *model) renderBlockReasons() string {
+	reasons := m.blockReasons()
+	if len(reasons) == 0 {
+		return ""
+	}
+
+	return dimStyle.Render("Cancellation blocked: "+strings.Join(reasons, ", ")) + "\n"
+}
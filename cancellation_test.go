@@ -0,0 +1,142 @@
+package mon_test
+
+import (
+	"context"
+	"io"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/apollosoftwarexyz/mon"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+var keyCtrlC = tea.KeyMsg{Type: tea.KeyCtrlC}
+
+func TestConfirmCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	m := mon.New("test").ConfirmCancellation()
+	mon.SetCancel(m, cancel)
+	m.AddTask().Apply()
+	m.AddTask().Apply().CompleteStep()
+
+	// The first press asks for confirmation.
+	mon.Press(m, keyCtrlC)
+	assert.NoError(t, ctx.Err())
+	assert.Contains(t, mon.View(m), "Press Ctrl+C again to abort (1 tasks running)")
+
+	// The second press cancels the context, but leaves the monitor displayed.
+	mon.Press(m, keyCtrlC)
+	assert.Error(t, ctx.Err())
+	assert.Equal(t, mon.ErrUserInterrupted, context.Cause(ctx))
+	assert.Contains(t, mon.View(m), "Aborting, press Ctrl+C again to force quit")
+
+	// The third press quits the monitor.
+	mon.Press(m, keyCtrlC)
+	assert.Empty(t, mon.View(m))
+	assert.Equal(t, mon.ErrUserInterrupted, mon.Cause(m))
+}
+
+func TestCancellation_cause(t *testing.T) {
+	m := mon.New("test")
+	mon.Press(m, keyCtrlC)
+	assert.Empty(t, mon.View(m))
+	assert.Equal(t, mon.ErrUserInterrupted, mon.Cause(m))
+}
+
+func TestCancellation_signals(t *testing.T) {
+	for sig, cause := range map[os.Signal]error{
+		syscall.SIGINT:  mon.ErrInterruptSignal,
+		syscall.SIGTERM: mon.ErrTerminateSignal,
+		syscall.SIGHUP:  mon.ErrHangupSignal,
+	} {
+		t.Run(sig.String(), func(t *testing.T) {
+			m := mon.New("test")
+			mon.Signal(m, sig)
+			assert.Empty(t, mon.View(m))
+			assert.Equal(t, cause, mon.Cause(m))
+		})
+	}
+
+	// SIGINT respects cancellation blocking, but other signals do not.
+	m := mon.New("test").BlockCancellation()
+	mon.Signal(m, syscall.SIGINT)
+	assert.NotEmpty(t, mon.View(m))
+	assert.Nil(t, mon.Cause(m))
+
+	mon.Signal(m, syscall.SIGTERM)
+	assert.Empty(t, mon.View(m))
+	assert.Equal(t, mon.ErrTerminateSignal, mon.Cause(m))
+}
+
+func TestBlockCancellation_hint(t *testing.T) {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	m := mon.New("test").ConfirmCancellation().BlockCancellation()
+	mon.SetCancel(m, cancel)
+	assert.NotContains(t, mon.View(m), "Cannot cancel now")
+
+	mon.Press(m, keyCtrlC, keyCtrlC)
+	assert.NoError(t, ctx.Err())
+	assert.Contains(t, mon.View(m), "Cannot cancel now")
+
+	// Once cancellation is allowed again, the user must still confirm.
+	m.AllowCancellation()
+	mon.Press(m, keyCtrlC)
+	assert.NoError(t, ctx.Err())
+	assert.Contains(t, mon.View(m), "Press Ctrl+C again to abort")
+}
+
+func TestBlockCancellationScope(t *testing.T) {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	m := mon.New("test")
+	mon.SetCancel(m, cancel)
+	assert.False(t, m.IsCancellationBlocked())
+
+	releaseSave := m.BlockCancellationScope("saving state")
+	releaseIndex := m.BlockCancellationScope("writing index")
+	assert.True(t, m.IsCancellationBlocked())
+	assert.Contains(t, mon.View(m), "Cancellation blocked: saving state, writing index")
+
+	// Cancellation is deferred whilst any scope is active.
+	mon.Press(m, keyCtrlC)
+	assert.NoError(t, ctx.Err())
+	assert.NotEmpty(t, mon.View(m))
+	assert.Contains(t, mon.View(m), "Cancelling once finished: saving state, writing index")
+
+	// Releasing a scope more than once has no further effect.
+	releaseSave()
+	releaseSave()
+	assert.True(t, m.IsCancellationBlocked())
+	assert.Contains(t, mon.View(m), "Cancellation blocked: writing index")
+
+	releaseIndex()
+	assert.False(t, m.IsCancellationBlocked())
+	assert.NotContains(t, mon.View(m), "Cancellation blocked")
+}
+
+// TestConfirmCancellation_program runs a real program to check that cancelling
+// the context does not kill the program before the user forces it to quit.
+func TestConfirmCancellation_program(t *testing.T) {
+	m := mon.New("test").ConfirmCancellation()
+	mon.SetOutput(m, io.Discard)
+	mon.SetProgramOptions(m, tea.WithInput(nil), tea.WithOutput(io.Discard))
+	m.AddTask().Apply()
+
+	ctx, cancel := m.Show(context.WithCancelCause(context.Background()))
+	assert.Eventually(t, func() bool { return mon.Running(m) }, time.Second, 10*time.Millisecond)
+
+	mon.Send(m, keyCtrlC)
+	mon.Send(m, keyCtrlC)
+	<-ctx.Done()
+	assert.Equal(t, mon.ErrUserInterrupted, context.Cause(ctx))
+
+	// The monitor remains displayed after the context is cancelled.
+	assert.Never(t, func() bool { return !mon.Running(m) }, 200*time.Millisecond, 10*time.Millisecond)
+
+	// The third press quits the monitor (without exiting the process).
+	mon.Send(m, keyCtrlC)
+	assert.Eventually(t, func() bool { return !mon.Running(m) }, time.Second, 10*time.Millisecond)
+	cancel(nil)
+}
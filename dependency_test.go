@@ -0,0 +1,108 @@
+package mon_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/apollosoftwarexyz/mon"
+	"github.com/stretchr/testify/assert"
+)
+
+// waitReady calls [mon.Task.WaitReady] in the background and returns a channel
+// that receives the result.
+func waitReady(ctx context.Context, task mon.Task) <-chan error {
+	result := make(chan error, 1)
+	go func() {
+		result <- task.WaitReady(ctx)
+	}()
+	return result
+}
+
+func TestDependsOn(t *testing.T) {
+	m := mon.New("test")
+	build := m.AddTask().Name("build").Apply()
+	lint := m.AddTask().Name("lint").Apply()
+	deploy := m.AddTask().Name("deploy").DependsOn(build).DependsOn(lint).Apply()
+
+	assert.Equal(t, []mon.Task{build, lint}, deploy.GetDependencies())
+	assert.True(t, deploy.IsBlocked())
+	assert.Equal(t, mon.TaskStateBlocked, deploy.GetState())
+	assert.Contains(t, mon.View(m), "waiting for: build, lint")
+
+	ready := waitReady(context.Background(), deploy)
+
+	// The task remains blocked until all of its dependencies complete.
+	build.CompleteStep()
+	select {
+	case <-ready:
+		assert.Fail(t, "task should not be ready")
+	case <-time.After(20 * time.Millisecond):
+	}
+	assert.True(t, deploy.IsBlocked())
+	assert.Contains(t, mon.View(m), "waiting for: lint")
+
+	lint.CompleteStep()
+	assert.NoError(t, <-ready)
+	assert.False(t, deploy.IsBlocked())
+	assert.Equal(t, mon.TaskStateRunning, deploy.GetState())
+
+	// Once ready, WaitReady returns immediately.
+	assert.NoError(t, deploy.WaitReady(context.Background()))
+}
+
+func TestDependsOn_failure(t *testing.T) {
+	m := mon.New("test")
+	build := m.AddTask().Name("build").Apply()
+	deploy := m.AddTask().Name("deploy").DependsOn(build).Apply()
+	notify := m.AddTask().Name("notify").DependsOn(build).SkipOnDependencyFailure().Apply()
+
+	ready := waitReady(context.Background(), deploy)
+	build.Error(mockError)
+
+	err := <-ready
+	var dependencyErr *mon.DependencyError
+	assert.True(t, errors.As(err, &dependencyErr))
+	assert.Equal(t, build, dependencyErr.Dependency)
+	assert.ErrorIs(t, err, mockError)
+	assert.EqualError(t, err, "dependency build failed")
+
+	// The dependent tasks fail or are skipped.
+	assert.Eventually(t, deploy.IsCompleted, time.Second, time.Millisecond)
+	assert.Equal(t, mon.TaskStateFailed, deploy.GetState())
+
+	assert.Eventually(t, notify.IsCompleted, time.Second, time.Millisecond)
+	assert.Equal(t, mon.TaskStateSkipped, notify.GetState())
+	assert.Contains(t, mon.View(m), "skipped: dependency build failed")
+}
+
+func TestWaitReady_cancelled(t *testing.T) {
+	m := mon.New("test")
+	build := m.AddTask().Apply()
+	deploy := m.AddTask().DependsOn(build).Apply()
+
+	// WaitReady returns the cause if the context is done first.
+	ctx, cancel := context.WithCancelCause(context.Background())
+	cancel(mockError)
+	assert.Equal(t, mockError, deploy.WaitReady(ctx))
+
+	// ...or the task's error if it completes first.
+	ready := waitReady(context.Background(), deploy)
+	deploy.Cancel(context.Canceled)
+	assert.Equal(t, context.Canceled, <-ready)
+	assert.False(t, deploy.IsBlocked())
+}
+
+func TestTask_Done(t *testing.T) {
+	task := createDefaultTask()
+
+	select {
+	case <-task.Done():
+		assert.Fail(t, "task should not be done")
+	default:
+	}
+
+	task.CompleteStep()
+	<-task.Done()
+}
@@ -0,0 +1,231 @@
+package mon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os/exec"
+	"regexp"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// execStderrTail is the number of lines from the end of a command's standard
+// error that are retained in an [ExitError].
+const execStderrTail = 5
+
+// execWaitDelay is how long [M.Exec] waits for the command's output to be
+// closed once the command has exited (or been killed), unless the command's
+// WaitDelay is set. This stops a background process that inherited the
+// output from keeping Exec waiting.
+const execWaitDelay = 500 * time.Millisecond
+
+// ExitError is recorded on a task run with [M.Exec] when the command exits
+// with a non-zero exit code.
+type ExitError struct {
+	// ExitCode of the command.
+	ExitCode int
+
+	// Stderr contains the last few lines the command wrote to standard error.
+	Stderr []string
+
+	err *exec.ExitError
+}
+
+func (e *ExitError) Error() string {
+	if len(e.Stderr) == 0 {
+		return fmt.Sprintf("exit code %d", e.ExitCode)
+	}
+
+	return fmt.Sprintf("exit code %d: %s", e.ExitCode, e.Stderr[len(e.Stderr)-1])
+}
+
+func (e *ExitError) Unwrap() error { return e.err }
+
+// ProgressParser extracts progress from a line of a command's output for
+// [M.Exec].
+//
+// If the line contains progress information, the parser returns the number of
+// completed and total steps, and true. Otherwise, it returns false.
+type ProgressParser func(line string) (completed uint64, total uint64, ok bool)
+
+var percentPattern = regexp.MustCompile(`(\d{1,3}(?:\.\d+)?)%`)
+
+// ParsePercent is a [ProgressParser] that extracts the last percentage (such
+// as "42%" or "42.5%") from a line, as a number of steps out of 100.
+func ParsePercent(line string) (uint64, uint64, bool) {
+	matches := percentPattern.FindAllStringSubmatch(line, -1)
+	if len(matches) == 0 {
+		return 0, 0, false
+	}
+
+	percent, err := strconv.ParseFloat(matches[len(matches)-1][1], 64)
+	if err != nil || percent > 100 {
+		return 0, 0, false
+	}
+
+	return uint64(math.Floor(percent)), 100, true
+}
+
+func (m *model) Exec(ctx context.Context, builder TaskBuilder, cmd *exec.Cmd, parsers ...ProgressParser) (Task, error) {
+	task := builder.Apply()
+
+	stderrTail := &lineTail{n: execStderrTail}
+	newProgress := func() *logWriter {
+		return &logWriter{
+			log:             func(line string) { applyProgress(task, parsers, line) },
+			carriageReturns: true,
+		}
+	}
+
+	// Each stream has its own writers, so that a partial line written to one
+	// is not joined with output from the other.
+	stdoutLog, stdoutProgress := task.Writer(), newProgress()
+	stderrLog, stderrProgress := task.Writer(), newProgress()
+	stderrTailWriter := &logWriter{log: stderrTail.append}
+	writers := []io.Writer{stdoutLog, stdoutProgress, stderrLog, stderrTailWriter, stderrProgress}
+
+	cmd.Stdout = combineWriters(cmd.Stdout, stdoutLog, stdoutProgress)
+	cmd.Stderr = combineWriters(cmd.Stderr, stderrLog, stderrTailWriter, stderrProgress)
+
+	// Wait for the task's dependencies before starting the command. If the
+	// context is done first (or already), the command is not started.
+	if err := task.WaitReady(ctx); err != nil {
+		task.Error(err)
+		return task, task.GetError()
+	}
+
+	if ctx.Err() != nil {
+		err := context.Cause(ctx)
+		task.Error(err)
+		return task, err
+	}
+
+	if cmd.WaitDelay == 0 {
+		cmd.WaitDelay = execWaitDelay
+	}
+
+	if err := cmd.Start(); err != nil {
+		task.Error(err)
+		return task, err
+	}
+
+	kill := func() {
+		_ = cmd.Process.Kill()
+	}
+	stop := context.AfterFunc(ctx, kill)
+	defer stop()
+	stopTask := context.AfterFunc(task.Context(), kill)
+	defer stopTask()
+
+	err := cmd.Wait()
+
+	// Print any final line that the command did not terminate with a
+	// newline.
+	for _, w := range writers {
+		if f, ok := w.(interface{ Flush() }); ok {
+			f.Flush()
+		}
+	}
+
+	// The command exited successfully, but a process it started kept its
+	// output open.
+	if errors.Is(err, exec.ErrWaitDelay) {
+		err = nil
+	}
+
+	var exitErr *exec.ExitError
+	switch {
+	case task.IsCancelled():
+		return task, task.GetError()
+	case ctx.Err() != nil:
+		err = context.Cause(ctx)
+	case errors.As(err, &exitErr):
+		err = &ExitError{
+			ExitCode: exitErr.ExitCode(),
+			Stderr:   stderrTail.lines(),
+			err:      exitErr,
+		}
+	}
+
+	if err != nil {
+		task.Error(err)
+		return task, err
+	}
+
+	if task.IsIndeterminate() {
+		task.CompleteStep()
+	} else {
+		// Complete the whole steps exactly (as large totals cannot be
+		// represented exactly as a float), and then any fractional
+		// remainder of the total.
+		task.SetCompletedSteps(task.GetTotalSteps())
+		task.SetCompletedAmount(task.GetTotalAmount())
+	}
+
+	return task, nil
+}
+
+// applyProgress updates the task with the first progress reported by the
+// parsers for the given line.
+//
+// The task is never completed by progress alone, so that it can still fail if
+// the command does.
+func applyProgress(task Task, parsers []ProgressParser, line string) {
+	for _, parse := range parsers {
+		completed, total, ok := parse(line)
+		if !ok || total == 0 {
+			continue
+		}
+
+		// The total may have been set to a fractional amount, which would
+		// otherwise be truncated by GetTotalSteps.
+		if task.GetTotalSteps() != total || task.GetTotalAmount() != float64(total) {
+			task.TotalSteps(total)
+		}
+
+		task.SetCompletedSteps(min(completed, total-1))
+		return
+	}
+}
+
+// combineWriters returns an [io.Writer] that writes to each of the non-nil
+// writers given.
+func combineWriters(writers ...io.Writer) io.Writer {
+	nonNil := make([]io.Writer, 0, len(writers))
+	for _, w := range writers {
+		if w != nil {
+			nonNil = append(nonNil, w)
+		}
+	}
+
+	return io.MultiWriter(nonNil...)
+}
+
+// lineTail retains the last n lines appended to it.
+type lineTail struct {
+	n int
+
+	mutex sync.Mutex
+	tail  []string
+}
+
+func (l *lineTail) append(line string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.tail = append(l.tail, line)
+	if len(l.tail) > l.n {
+		l.tail = l.tail[1:]
+	}
+}
+
+func (l *lineTail) lines() []string {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return slices.Clone(l.tail)
+}
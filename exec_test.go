@@ -0,0 +1,209 @@
+package mon_test
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/apollosoftwarexyz/mon"
+	"github.com/stretchr/testify/assert"
+)
+
+// shell returns a command that runs the given script with sh, skipping the
+// test if sh is not available.
+func shell(t *testing.T, script string) *exec.Cmd {
+	t.Helper()
+
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh is not available")
+	}
+
+	return exec.Command("sh", "-c", script)
+}
+
+func TestM_Exec(t *testing.T) {
+	m := mon.New("test")
+	cmd := shell(t, "echo out; echo err >&2")
+
+	task, err := m.Exec(context.Background(), m.AddTask().Name("echo"), cmd)
+	assert.NoError(t, err)
+	assert.Equal(t, "echo", task.GetName())
+	assert.True(t, task.IsCompleted())
+	assert.False(t, task.IsError())
+	assert.ElementsMatch(t, []string{"out", "err"}, task.GetLog())
+}
+
+func TestM_Exec_exitCode(t *testing.T) {
+	m := mon.New("test")
+	cmd := shell(t, "echo first >&2; echo last >&2; exit 3")
+
+	task, err := m.Exec(context.Background(), m.AddTask(), cmd)
+
+	var exitErr *mon.ExitError
+	assert.True(t, errors.As(err, &exitErr))
+	assert.Equal(t, 3, exitErr.ExitCode)
+	assert.Equal(t, []string{"first", "last"}, exitErr.Stderr)
+	assert.EqualError(t, err, "exit code 3: last")
+
+	assert.True(t, task.IsError())
+	assert.Equal(t, err, task.GetError())
+
+	var cmdErr *exec.ExitError
+	assert.True(t, errors.As(err, &cmdErr))
+}
+
+func TestM_Exec_unterminated(t *testing.T) {
+	m := mon.New("test")
+	cmd := shell(t, "printf 'fatal: boom' >&2; exit 2")
+
+	task, err := m.Exec(context.Background(), m.AddTask(), cmd)
+
+	// The final line is kept, even without a trailing newline.
+	var exitErr *mon.ExitError
+	assert.True(t, errors.As(err, &exitErr))
+	assert.Equal(t, []string{"fatal: boom"}, exitErr.Stderr)
+	assert.EqualError(t, err, "exit code 2: fatal: boom")
+	assert.Equal(t, []string{"fatal: boom"}, task.GetLog())
+}
+
+func TestM_Exec_interleaved(t *testing.T) {
+	m := mon.New("test")
+	cmd := shell(t, `printf 'out '; printf 'err ' >&2; echo 1; echo 2 >&2; printf '50%%' >&2`)
+
+	task, err := m.Exec(context.Background(), m.AddTask().TotalSteps(100), cmd, mon.ParsePercent)
+	assert.NoError(t, err)
+
+	// Partial lines written to each stream are not joined with each other.
+	assert.ElementsMatch(t, []string{"out 1", "err 2", "50%"}, task.GetLog())
+}
+
+func TestM_Exec_progress(t *testing.T) {
+	m := mon.New("test")
+	cmd := shell(t, `printf '10%%\r55.5%%\r'; echo 'almost 100%'; echo done`)
+
+	var progress []uint64
+	recordProgress := func(line string) (uint64, uint64, bool) {
+		completed, total, ok := mon.ParsePercent(line)
+		if ok {
+			progress = append(progress, completed)
+		}
+		return completed, total, ok
+	}
+
+	task, err := m.Exec(context.Background(), m.AddTask(), cmd, recordProgress)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{10, 55, 100}, progress)
+
+	// Progress alone does not complete the task, but the successful exit does.
+	assert.Equal(t, uint64(100), task.GetTotalSteps())
+	assert.Equal(t, uint64(100), task.GetCompleteSteps())
+	assert.True(t, task.IsCompleted())
+
+	// Only the final state of each line is logged.
+	assert.Equal(t, []string{"almost 100%", "done"}, task.GetLog())
+}
+
+func TestM_Exec_fractionalTotal(t *testing.T) {
+	m := mon.New("test")
+
+	// The successful exit completes a fractional total, rather than only its
+	// whole steps.
+	task, err := m.Exec(context.Background(), m.AddTask().TotalAmount(2.5), shell(t, "exit 0"))
+	assert.NoError(t, err)
+	assert.Equal(t, 2.5, task.GetCompletedAmount())
+	assert.True(t, task.IsCompleted())
+
+	select {
+	case <-task.Done():
+	default:
+		assert.Fail(t, "task is not done")
+	}
+}
+
+func TestM_Exec_cancel(t *testing.T) {
+	m := mon.New("test")
+	cause := errors.New("cancelled")
+
+	// The command is not started if the context is already cancelled.
+	ctx, cancel := context.WithCancelCause(context.Background())
+	cancel(cause)
+
+	task, err := m.Exec(ctx, m.AddTask(), shell(t, "exit 0"))
+	assert.Equal(t, cause, err)
+	assert.Equal(t, cause, task.GetError())
+
+	// Otherwise, the command is killed when the context is cancelled.
+	ctx, cancel = context.WithCancelCause(context.Background())
+	time.AfterFunc(50*time.Millisecond, func() { cancel(cause) })
+
+	task, err = m.Exec(ctx, m.AddTask(), shell(t, "exec sleep 10"))
+	assert.Equal(t, cause, err)
+	assert.Equal(t, cause, task.GetError())
+}
+
+func TestM_Exec_cancel_background(t *testing.T) {
+	m := mon.New("test")
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	// The background child keeps the output open after the shell is killed,
+	// but Exec does not wait for it to exit.
+	start := time.Now()
+	_, err := m.Exec(ctx, m.AddTask(), shell(t, "sleep 3 & wait"))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 2*time.Second)
+}
+
+func TestM_Exec_background(t *testing.T) {
+	m := mon.New("test")
+
+	// A command that exits successfully succeeds, even if a process it
+	// started in the background keeps the output open.
+	start := time.Now()
+	task, err := m.Exec(context.Background(), m.AddTask(), shell(t, "echo started; sleep 3 &"))
+	assert.NoError(t, err)
+	assert.True(t, task.IsCompleted())
+	assert.Equal(t, []string{"started"}, task.GetLog())
+	assert.Less(t, time.Since(start), 2*time.Second)
+}
+
+func TestM_Exec_cancelTask(t *testing.T) {
+	m := mon.New("test")
+	builder := m.AddTask().ID("sleep")
+	time.AfterFunc(50*time.Millisecond, func() {
+		task, _ := m.Task("sleep")
+		task.Cancel(mockError)
+	})
+
+	task, err := m.Exec(context.Background(), builder, shell(t, "exec sleep 10"))
+	assert.Equal(t, mockError, err)
+	assert.True(t, task.IsCancelled())
+}
+
+func TestM_Exec_notFound(t *testing.T) {
+	m := mon.New("test")
+	cmd := exec.Command("mon-command-that-does-not-exist")
+
+	task, err := m.Exec(context.Background(), m.AddTask(), cmd)
+	assert.Error(t, err)
+	assert.True(t, task.IsError())
+}
+
+func TestParsePercent(t *testing.T) {
+	completed, total, ok := mon.ParsePercent("Receiving objects:  42% (42/100)")
+	assert.True(t, ok)
+	assert.Equal(t, uint64(42), completed)
+	assert.Equal(t, uint64(100), total)
+
+	completed, _, ok = mon.ParsePercent("from 10% to 99.9%")
+	assert.True(t, ok)
+	assert.Equal(t, uint64(99), completed)
+
+	_, _, ok = mon.ParsePercent("no progress here")
+	assert.False(t, ok)
+
+	_, _, ok = mon.ParsePercent("250%")
+	assert.False(t, ok)
+}
@@ -0,0 +1,103 @@
+package mon
+
+import (
+	"context"
+	"io"
+	"os"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// MaxExpiredTasks is the maximum number of expired tasks that an interactive
+// monitor keeps.
+const MaxExpiredTasks = maxExpiredTasks
+
+// SetOutput replaces the writer that the monitor falls back to when it is not
+// being shown.
+func SetOutput(m M, w io.Writer) {
+	m.(*model).out = w
+}
+
+// Interactive enables the interactive controls of the monitor as if it were
+// being shown in a terminal of the given height.
+func Interactive(m M, height int) {
+	m.(*model).interactive = true
+	m.(*model).height = height
+}
+
+// Press sends the given key presses to the monitor.
+func Press(m M, keys ...tea.KeyMsg) {
+	for _, key := range keys {
+		m.(*model).Update(key)
+	}
+}
+
+// Tick delivers a refresh tick to the monitor.
+func Tick(m M) {
+	m.(*model).Update(tickMsg{tag: m.(*model).tag})
+}
+
+// UpdateTerminal updates the window title and taskbar progress, returning the
+// command that sets the window title (if it has changed).
+func UpdateTerminal(m M) tea.Cmd {
+	return m.(*model).updateTerminal()
+}
+
+// ResetTerminal clears the window title and taskbar progress, as when the
+// monitor is closed.
+func ResetTerminal(m M) {
+	m.(*model).resetTerminal()
+}
+
+// Expire backdates the completion of the task so that its retention window
+// has passed.
+func Expire(t Task) {
+	t.(*task).stateMutex.Lock()
+	defer t.(*task).stateMutex.Unlock()
+	t.(*task).endTime = t.(*task).endTime.Add(-failedTaskRetention - time.Second)
+}
+
+// SetProgramOptions sets additional options for the bubbletea program created
+// by [M.Show].
+func SetProgramOptions(m M, opts ...tea.ProgramOption) {
+	m.(*model).programOptions = opts
+}
+
+// Send sends the given message to the running bubbletea program.
+func Send(m M, msg tea.Msg) {
+	m.(*model).prog.Send(msg)
+}
+
+// Running returns true if the bubbletea program is running.
+func Running(m M) bool {
+	return m.(*model).running.Load()
+}
+
+// Summary renders the summary of failed tasks printed when the monitor exits.
+func Summary(m M) string {
+	return m.(*model).renderSummary()
+}
+
+// View renders the monitor.
+func View(m M) string {
+	return m.(*model).View()
+}
+
+// SetCancel sets the function used by the monitor to cancel its context, as
+// if it had been given to [M.Show].
+func SetCancel(m M, cancel context.CancelCauseFunc) {
+	m.(*model).cancel = cancel
+}
+
+// Signal sends the given signal to the monitor as if the process had received
+// it.
+func Signal(m M, sig os.Signal) {
+	m.(*model).Update(signalMsg{signal: sig})
+}
+
+// Cause returns the cause that the monitor will give to its context when it
+// exits.
+func Cause(m M) error {
+	return m.(*model).cause
+}
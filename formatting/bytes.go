@@ -3,77 +3,254 @@ package formatting
 import (
 	"fmt"
 	"math"
+	"math/big"
+	"math/bits"
+	"strings"
+)
+
+// defaultBytesPrecision is the number of decimal places rendered by
+// [BytesUnit] when [BytesUnit.Precision] is zero.
+const defaultBytesPrecision = 3
+
+// maxBytesPrecision is the largest number of decimal places rendered by
+// [BytesUnit].
+const maxBytesPrecision = 9
+
+var (
+	iecByteUnits = []string{"byte", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+	siByteUnits  = []string{"byte", "kB", "MB", "GB", "TB", "PB", "EB"}
+	iecBitUnits  = []string{"bit", "Kib", "Mib", "Gib", "Tib", "Pib", "Eib"}
+	siBitUnits   = []string{"bit", "kb", "Mb", "Gb", "Tb", "Pb", "Eb"}
 )
 
 // BytesUnit renders discrete numbers of bytes using [Bytes].
 //
 // For clarity, the units are not abbreviated in the progress formatting
 // variant.
-type BytesUnit struct{}
+//
+// The zero value renders bytes with binary (IEC) units and three decimal
+// places, exactly as [Bytes] does.
+type BytesUnit struct {
+	// SI selects decimal (1,000-based) units such as kB and MB, as used for
+	// network throughput and disk capacities, instead of binary (1,024-based)
+	// units such as KiB and MiB.
+	SI bool
+
+	// Bits renders values as a number of bits (e.g., "Mb" rather than "MB").
+	// The rendered values are still given in bytes.
+	Bits bool
+
+	// Precision is the number of decimal places rendered for values larger
+	// than the base unit (up to 9). If zero, three decimal places are
+	// rendered. If negative, only whole units are rendered.
+	Precision int
+}
 
-func (*BytesUnit) Render(value uint64) string {
-	return Bytes(value)
+func (b *BytesUnit) Render(value uint64) string {
+	units, base := b.units()
+	return formatBytes(value, units, base, b.Bits, b.precision())
 }
 
 func (b *BytesUnit) RenderProgress(current uint64, total uint64) string {
 	return fmt.Sprintf("%s / %s", b.Render(current), b.Render(total))
 }
 
+// RenderFloat renders the (possibly fractional) number of bytes, such as a
+// number of megabytes converted to bytes. The value is truncated to a whole
+// number of bytes or, if [BytesUnit.Bits] is set, bits.
+func (b *BytesUnit) RenderFloat(value float64) string {
+	// Fractional bytes are rendered as whole bits, unless the number of bits
+	// is too large to count.
+	if b.Bits && value*8 < math.MaxUint64 {
+		units, base := b.units()
+		return formatBytes(truncateFloat(value*8), units, base, false, b.precision())
+	}
+
+	return b.Render(truncateFloat(value))
+}
+
+func (b *BytesUnit) RenderFloatProgress(current float64, total float64) string {
+	return fmt.Sprintf("%s / %s", b.RenderFloat(current), b.RenderFloat(total))
+}
+
+func (b *BytesUnit) RenderRate(perSecond float64) string {
+	return formatRate(perSecond, func(value float64) string {
+		return b.Render(uint64(math.Round(value)))
+	})
+}
+
+// Parse the number of bytes in s using [ParseBytes]. Any of the units that
+// [BytesUnit] renders are accepted, regardless of the unit's configuration.
+func (*BytesUnit) Parse(s string) (uint64, error) {
+	return ParseBytes(s)
+}
+
+// precision returns the number of decimal places for the unit's
+// configuration.
+func (b *BytesUnit) precision() int {
+	precision := b.Precision
+	if precision == 0 {
+		precision = defaultBytesPrecision
+	}
+
+	return min(max(precision, 0), maxBytesPrecision)
+}
+
+// units returns the unit names and base for the unit's configuration.
+func (b *BytesUnit) units() ([]string, uint64) {
+	switch {
+	case b.SI && b.Bits:
+		return siBitUnits, 1000
+	case b.SI:
+		return siByteUnits, 1000
+	case b.Bits:
+		return iecBitUnits, 1024
+	default:
+		return iecByteUnits, 1024
+	}
+}
+
 // Bytes formats the given value as a number of bytes.
 //
 // Values up to 1,024 bytes are formatted as "<value> bytes". Larger values
 // are formatted according to their nearest SI unit.
+//
+// The number (and the word "bytes") is rendered according to the current
+// locale (see [SetLocale]). For decimal units, bits or a different precision,
+// use [BytesUnit].
 func Bytes(value uint64) string {
-	if value == 0 {
-		return "0 bytes"
-	} else if value == 1 {
-		return "1 byte"
+	return formatBytes(value, iecByteUnits, 1024, false, defaultBytesPrecision)
+}
+
+// formatBytes formats the given number of bytes with the largest unit (whose
+// multiples are powers of base) that the value is at least one of.
+func formatBytes(value uint64, units []string, base uint64, asBits bool, precision int) string {
+	// Bits are counted by dividing the number of bytes by an eighth of each
+	// unit's divisor, rather than by multiplying the number of bytes by eight,
+	// which could overflow.
+	divisor := func(unitIdx int) uint64 {
+		if asBits {
+			return ipow(base, uint64(unitIdx)) / 8
+		}
+
+		return ipow(base, uint64(unitIdx))
+	}
+
+	unitIdx := 0
+	for unitIdx+1 < len(units) && value >= divisor(unitIdx+1) {
+		unitIdx++
 	}
 
-	// Compute the unit index with ilog2(value)/ilog2(1024)
-	// ilog2(1024) = 10 so this has been precomputed.
-	units := []string{"bytes", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
-	unitIdx := ilog2(value) / 10
+	l := CurrentLocale()
 
-	// If the index is 0, just return the value as-is with the first suffix.
+	// If the index is 0, just return the value as-is with the first suffix
+	// (which is a word, rather than a symbol).
 	if unitIdx == 0 {
-		return fmt.Sprintf("%d %s", value, units[0])
-	}
+		if asBits {
+			value *= 8
+		}
 
-	// We compute the divisor for the unit which is 1024^unitIdx. That is,
-	// unitIdx=1 => we divide by 1024, unitIdx=2 => 1024*1024, etc.,
-	unitDivisor := ipow(1024, unitIdx)
+		return fmt.Sprintf("%s %s", l.formatUint(value), l.word(units[0], value))
+	}
 
 	// We perform integer division to get the whole unit value. This is
 	// preferable to alternative methods because it preserves precision by doing
-	// the division first, then converting to float64.
-	unitValue := float64(value / unitDivisor)
+	// the division first.
+	unitDivisor := divisor(unitIdx)
+	unitValue := value / unitDivisor
 	remainder := value % unitDivisor
-	decimalRemainder := float64(remainder) / float64(ipow(1024, unitIdx)) // compute the decimal remainder
-	decimalRemainderExtra := decimalRemainder * 10000                     // multiply decimal remainder to 3sf + 1 extra precision.
-	decimalRemainder = float64(uint64((decimalRemainderExtra + 5) / 10))  // round half-up
+	if precision == 0 {
+		return fmt.Sprintf("%s %s", l.formatUint(unitValue), units[unitIdx])
+	}
 
-	// If we're here, the value didn't round evenly, so ensure we account for
-	// lost precision.
-	decimalRemainder = math.Min(decimalRemainder, 999)
+	// Scale the remainder to the requested number of decimal places, rounding
+	// half-up. The intermediate product may exceed 64 bits, so it is computed
+	// with 128-bit arithmetic: round(r*s/d) = floor((2*r*s + d) / (2*d)).
+	scale := ipow(10, uint64(precision))
+	hi, lo := bits.Mul64(remainder, 2*scale)
+	lo, carry := bits.Add64(lo, unitDivisor, 0)
+	decimalRemainder, _ := bits.Div64(hi+carry, lo, 2*unitDivisor)
 
-	return fmt.Sprintf("%0.3f %s", unitValue+(decimalRemainder/1000), units[unitIdx])
+	// Never round up to the next whole unit, as that would overstate the
+	// value.
+	decimalRemainder = min(decimalRemainder, scale-1)
+
+	return fmt.Sprintf("%s %s", l.formatFixed(unitValue, decimalRemainder, precision), units[unitIdx])
 }
 
-// ilog2 computes the integer log of n by shifting whilst there are still set
-// bits. The result is the counter which is returned as a [uint64].
-func ilog2(n uint64) uint64 {
-	var result uint64 = 0
-
-	// We don't have a do-while loop in Go, so we just perform the first step
-	// immediately.
-	n = n >> 1
-	for n > 0 {
-		n = n >> 1
-		result++
+// ParseBytes parses a number of bytes formatted by [Bytes] or [BytesUnit],
+// such as "1.5 MiB", "10kB", "100 Mb" or "512 bytes".
+//
+// Unit names are case-sensitive because, for example, "Mb" (megabits) and
+// "MB" (megabytes) differ. A number without a unit, or with the unit "B", is
+// a number of bytes. Fractional numbers of bytes are rounded to the nearest
+// byte.
+//
+// Numbers and words are parsed according to the current locale (see
+// [SetLocale]), although the English words are always accepted.
+func ParseBytes(s string) (uint64, error) {
+	l := CurrentLocale()
+	number, unit, err := l.splitNumber(strings.TrimSpace(s))
+	if err != nil {
+		return 0, err
 	}
 
-	return result
+	unit = strings.TrimSpace(unit)
+
+	value, ok := new(big.Rat).SetString(number)
+	if !ok {
+		return 0, fmt.Errorf("%w: %q is not a number of bytes", ErrSyntax, s)
+	}
+
+	if word, ok := l.parseWord(unit, "byte", "bit"); ok {
+		unit = word
+	}
+
+	multiplier, ok := bytesMultiplier(unit)
+	if !ok {
+		return 0, fmt.Errorf("%w: %q has an unknown unit %q", ErrSyntax, s, unit)
+	}
+
+	return roundRat(value.Mul(value, multiplier), s)
+}
+
+// bytesMultiplier returns the number of bytes in the given unit.
+func bytesMultiplier(unit string) (*big.Rat, bool) {
+	switch unit {
+	case "", "B":
+		return big.NewRat(1, 1), true
+	case "b":
+		return big.NewRat(1, 8), true
+	}
+
+	for _, table := range []struct {
+		units []string
+		base  uint64
+		bits  bool
+	}{
+		{iecByteUnits, 1024, false},
+		{siByteUnits, 1000, false},
+		{iecBitUnits, 1024, true},
+		{siBitUnits, 1000, true},
+	} {
+		for i, name := range table.units {
+			if unit != name {
+				continue
+			}
+
+			multiplier := new(big.Rat).SetFrac(
+				new(big.Int).Exp(new(big.Int).SetUint64(table.base), big.NewInt(int64(i)), nil),
+				big.NewInt(1),
+			)
+			if table.bits {
+				multiplier.Quo(multiplier, big.NewRat(8, 1))
+			}
+
+			return multiplier, true
+		}
+	}
+
+	return nil, false
 }
 
 // ipow computes the integer power of a by multiplying it together b times.
@@ -26,6 +26,54 @@ func TestBytesUnit_RenderProgress(t *testing.T) {
 	assert.Equal(t, "1.000 GiB / 1.001 GiB", unit.RenderProgress(gibibyte, gibibyte+mebibyte))
 }
 
+func TestBytesUnit_Render_si(t *testing.T) {
+	unit := &formatting.BytesUnit{SI: true}
+	assert.Equal(t, "999 bytes", unit.Render(999))
+	assert.Equal(t, "1.000 kB", unit.Render(1000))
+	assert.Equal(t, "1.024 kB", unit.Render(kibibyte))
+	assert.Equal(t, "1.500 MB", unit.Render(1_500_000))
+	assert.Equal(t, "999.999 MB", unit.Render(999_999_999))
+	assert.Equal(t, "18.447 EB", unit.Render(1<<64-1))
+}
+
+func TestBytesUnit_Render_bits(t *testing.T) {
+	unit := &formatting.BytesUnit{Bits: true}
+	assert.Equal(t, "0 bits", unit.Render(0))
+	assert.Equal(t, "8 bits", unit.Render(1))
+	assert.Equal(t, "1016 bits", unit.Render(127))
+	assert.Equal(t, "1.000 Kib", unit.Render(128))
+	assert.Equal(t, "8.000 Kib", unit.Render(kibibyte))
+	assert.Equal(t, "127.999 Eib", unit.Render(1<<64-1))
+
+	unit = &formatting.BytesUnit{SI: true, Bits: true}
+	assert.Equal(t, "1.000 kb", unit.Render(125))
+	assert.Equal(t, "12.500 Mb", unit.Render(1_562_500))
+}
+
+func TestBytesUnit_Render_precision(t *testing.T) {
+	unit := &formatting.BytesUnit{Precision: 1}
+	assert.Equal(t, "702 bytes", unit.Render(702))
+	assert.Equal(t, "1.5 KiB", unit.Render(1536))
+	assert.Equal(t, "1.9 KiB", unit.Render(2047))
+
+	unit = &formatting.BytesUnit{Precision: 6}
+	assert.Equal(t, "1.000977 KiB", unit.Render(kibibyte+1))
+
+	// Negative precision renders only whole units, which are never rounded up.
+	unit = &formatting.BytesUnit{Precision: -1}
+	assert.Equal(t, "1 KiB", unit.Render(2047))
+	assert.Equal(t, "15 EiB", unit.Render(1<<64-1))
+}
+
+func TestBytesUnit_Parse(t *testing.T) {
+	var unit formatting.Parser = &formatting.BytesUnit{SI: true}
+
+	// All units are accepted, regardless of the unit's configuration.
+	value, err := unit.Parse("1.5GiB")
+	assert.NoError(t, err)
+	assert.Equal(t, gibibyte+gibibyte/2, value)
+}
+
 func TestBytes(t *testing.T) {
 	assert.Equal(t, "0 bytes", formatting.Bytes(0))
 	assert.Equal(t, "1 byte", formatting.Bytes(1))
@@ -80,6 +128,61 @@ func TestBytes(t *testing.T) {
 	assert.Equal(t, "15.999 EiB", formatting.Bytes(1<<64-1))
 }
 
+func TestParseBytes(t *testing.T) {
+	for input, expected := range map[string]uint64{
+		"0":            0,
+		"512":          512,
+		"1 byte":       1,
+		"702 bytes":    702,
+		"64B":          64,
+		"1.000 KiB":    kibibyte,
+		"1.5MiB":       mebibyte + mebibyte/2,
+		" 3.123 GiB ":  3353295716,
+		"10 kB":        10_000,
+		"1.5 MB":       1_500_000,
+		"8 bits":       1,
+		"12 b":         2,
+		"1 Kib":        128,
+		"100 Mb":       12_500_000,
+		"15.999 EiB":   18445591152204944769,
+		"18.446744 EB": 18_446_744_000_000_000_000,
+	} {
+		actual, err := formatting.ParseBytes(input)
+		if assert.NoError(t, err, input) {
+			assert.Equal(t, expected, actual, input)
+		}
+	}
+
+	for _, input := range []string{"", "bytes", "-1 KiB", "1e3 bytes", "1 KB", "1 XiB", "1.2.3 MiB"} {
+		_, err := formatting.ParseBytes(input)
+		assert.ErrorIs(t, err, formatting.ErrSyntax, input)
+	}
+
+	_, err := formatting.ParseBytes("16 EiB")
+	assert.ErrorIs(t, err, formatting.ErrRange)
+}
+
+func TestParseBytes_roundTrip(t *testing.T) {
+	for _, unit := range []*formatting.BytesUnit{
+		{},
+		{SI: true},
+		{Bits: true},
+		{SI: true, Bits: true, Precision: 1},
+	} {
+		values := []uint64{0, 1, 702, kibibyte, 1_500_000, gibibyte + mebibyte}
+		for value := uint64(1); value < 1<<62; value = value*3 + 7 {
+			values = append(values, value)
+		}
+
+		for _, value := range values {
+			parsed, err := formatting.ParseBytes(unit.Render(value))
+			if assert.NoError(t, err) {
+				assert.Equal(t, unit.Render(value), unit.Render(parsed))
+			}
+		}
+	}
+}
+
 func BenchmarkBytes(b *testing.B) {
 	g := 3.525 * float64(gibibyte)
 	ug := uint64(g)
@@ -0,0 +1,144 @@
+package formatting
+
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+	"strings"
+)
+
+// countPrefixes are the metric prefixes used to compact counts, where the
+// prefix at index i multiplies the value by 1,000^i.
+var countPrefixes = []string{"", "k", "M", "G", "T", "P", "E"}
+
+// defaultCountFigures is the number of significant figures that compacted
+// counts are rendered with by default.
+const defaultCountFigures = 3
+
+// maxCountFigures is the largest number of significant figures that compacted
+// counts are rendered with.
+const maxCountFigures = 9
+
+// CountUnit renders discrete counts compacted with metric prefixes (e.g.,
+// "12.3M" and "12.3M / 40.0M"), so that large counts stay narrow.
+type CountUnit struct {
+	// Figures is the number of significant figures rendered (up to 9). If
+	// zero, three significant figures are rendered. The whole part of a
+	// count is always rendered in full (e.g., "123k" with one significant
+	// figure).
+	Figures int
+}
+
+func (c *CountUnit) Render(value uint64) string {
+	return compactCount(CurrentLocale(), value, countFigures(c.Figures))
+}
+
+func (c *CountUnit) RenderProgress(current uint64, total uint64) string {
+	return fmt.Sprintf("%s / %s", c.Render(current), c.Render(total))
+}
+
+func (c *CountUnit) RenderRate(perSecond float64) string {
+	l := CurrentLocale()
+	return formatRate(perSecond, func(value float64) string {
+		return formatCountRateValue(l, value, countFigures(c.Figures))
+	})
+}
+
+// Parse a count rendered by [Count] or the unit using [ParseCount].
+func (*CountUnit) Parse(s string) (uint64, error) {
+	return ParseCount(s)
+}
+
+// Count formats the given count with a metric prefix (k, M, G, T, P or E) and
+// three significant figures (e.g., "1.23k" for 1,234 and "12.3M" for
+// 12,345,678). Counts below 1,000 are formatted in full.
+//
+// Counts are truncated, rather than rounded, so they are never overstated.
+// The number is rendered according to the current locale (see [SetLocale]).
+// For a different number of significant figures, use [CountUnit].
+func Count(value uint64) string {
+	return compactCount(CurrentLocale(), value, defaultCountFigures)
+}
+
+// ParseCount parses a count formatted by [Count] (e.g., "12.3M"), or a number
+// without a metric prefix. Numbers are parsed according to the current locale
+// (see [SetLocale]), and fractional counts are rounded to the nearest whole
+// count.
+func ParseCount(s string) (uint64, error) {
+	value, rest, err := parseCount(CurrentLocale(), s)
+	if err != nil {
+		return 0, err
+	}
+
+	if rest != "" {
+		return 0, fmt.Errorf("%w: %q has an unknown unit %q", ErrSyntax, s, rest)
+	}
+
+	return value, nil
+}
+
+// countFigures returns the number of significant figures to render compacted
+// counts with, given the configured number of significant figures.
+func countFigures(figures int) int {
+	if figures <= 0 {
+		return defaultCountFigures
+	}
+
+	return min(figures, maxCountFigures)
+}
+
+// compactCount renders the given count with a metric prefix (e.g., "1.23k"
+// for 1,234) and the given number of significant figures. Counts below 1,000
+// are rendered in full.
+//
+// Counts are truncated, rather than rounded, so they are never overstated.
+func compactCount(l *Locale, value uint64, figures int) string {
+	prefixIdx := 0
+	for prefixIdx+1 < len(countPrefixes) && value >= ipow(1000, uint64(prefixIdx+1)) {
+		prefixIdx++
+	}
+
+	if prefixIdx == 0 {
+		return l.formatUint(value)
+	}
+
+	divisor := ipow(1000, uint64(prefixIdx))
+	whole := value / divisor
+	remainder := value % divisor
+
+	// The whole part has between one and three digits, and the fractional
+	// part makes up the remaining significant figures.
+	decimals := max(figures-len(fmt.Sprint(whole)), 0)
+	hi, lo := bits.Mul64(remainder, ipow(10, uint64(decimals)))
+	fraction, _ := bits.Div64(hi, lo, divisor)
+
+	return l.formatFixed(whole, fraction, decimals) + countPrefixes[prefixIdx]
+}
+
+// parseCount parses the leading count of s, which may be compacted with a
+// metric prefix (e.g., "1.23k"), returning the remainder of s.
+func parseCount(l *Locale, s string) (uint64, string, error) {
+	number, rest, err := l.splitNumber(strings.TrimSpace(s))
+	if err != nil {
+		return 0, "", err
+	}
+
+	value, ok := new(big.Rat).SetString(number)
+	if !ok {
+		return 0, "", fmt.Errorf("%w: %q is not a number", ErrSyntax, s)
+	}
+
+	// The prefix must immediately follow the number and be followed by a
+	// space (or nothing), so that a word starting with the same letter (e.g.,
+	// "5 kilograms" or "5kilograms") is not mistaken for one.
+	for i, prefix := range countPrefixes[1:] {
+		if after, ok := strings.CutPrefix(rest, prefix); ok && (after == "" || after[0] == ' ') {
+			value.Mul(value, new(big.Rat).SetUint64(ipow(1000, uint64(i+1))))
+			rest = after
+			break
+		}
+	}
+
+	count, err := roundRat(value, s)
+	return count, strings.TrimSpace(rest), err
+}
@@ -0,0 +1,82 @@
+package formatting_test
+
+import (
+	"testing"
+
+	"github.com/apollosoftwarexyz/mon/formatting"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCountUnit_Render(t *testing.T) {
+	unit := &formatting.CountUnit{}
+	assert.Equal(t, "12.3M", unit.Render(12_345_678))
+
+	unit = &formatting.CountUnit{Figures: 5}
+	assert.Equal(t, "12.345M", unit.Render(12_345_678))
+	assert.Equal(t, "999", unit.Render(999))
+
+	// The whole part is always rendered in full.
+	unit = &formatting.CountUnit{Figures: 1}
+	assert.Equal(t, "1k", unit.Render(1999))
+	assert.Equal(t, "123k", unit.Render(123_456))
+}
+
+func TestCountUnit_RenderProgress(t *testing.T) {
+	unit := &formatting.CountUnit{}
+	assert.Equal(t, "12.3M / 40.0M", unit.RenderProgress(12_345_678, 40_000_000))
+	assert.Equal(t, "0 / 5", unit.RenderProgress(0, 5))
+
+	rows := &formatting.NounUnit{Singular: "row", Compact: true}
+	assert.Equal(t, "12.3M / 40.0M rows", rows.RenderProgress(12_345_678, 40_000_000))
+
+	rows.Figures = 2
+	assert.Equal(t, "12M / 40M rows", rows.RenderProgress(12_345_678, 40_000_000))
+}
+
+func TestCount(t *testing.T) {
+	assert.Equal(t, "0", formatting.Count(0))
+	assert.Equal(t, "1", formatting.Count(1))
+	assert.Equal(t, "999", formatting.Count(999))
+	assert.Equal(t, "1.00k", formatting.Count(1000))
+	assert.Equal(t, "1.99k", formatting.Count(1999))
+	assert.Equal(t, "12.3k", formatting.Count(12_345))
+	assert.Equal(t, "123k", formatting.Count(123_456))
+	assert.Equal(t, "999k", formatting.Count(999_999))
+	assert.Equal(t, "1.00M", formatting.Count(1_000_000))
+	assert.Equal(t, "12.3M", formatting.Count(12_345_678))
+	assert.Equal(t, "1.23G", formatting.Count(1_234_567_890))
+	assert.Equal(t, "1.00T", formatting.Count(1_000_000_000_000))
+	assert.Equal(t, "18.4E", formatting.Count(1<<64-1))
+}
+
+func TestParseCount(t *testing.T) {
+	for input, expected := range map[string]uint64{
+		"0":       0,
+		"999":     999,
+		"1.00k":   1000,
+		"1.5k":    1500,
+		" 12.3M ": 12_300_000,
+		"1.23G":   1_230_000_000,
+		"18.4E":   18_400_000_000_000_000_000,
+	} {
+		actual, err := formatting.ParseCount(input)
+		if assert.NoError(t, err, input) {
+			assert.Equal(t, expected, actual, input)
+		}
+	}
+
+	for _, input := range []string{"", "k", "1 rows", "1.2.3k", "1 k"} {
+		_, err := formatting.ParseCount(input)
+		assert.ErrorIs(t, err, formatting.ErrSyntax, input)
+	}
+
+	_, err := formatting.ParseCount("18.5E")
+	assert.ErrorIs(t, err, formatting.ErrRange)
+
+	for value := uint64(1); value < 1<<62; value = value*3 + 7 {
+		parsed, err := formatting.ParseCount(formatting.Count(value))
+		if assert.NoError(t, err) {
+			assert.Equal(t, formatting.Count(value), formatting.Count(parsed))
+		}
+	}
+}
@@ -2,6 +2,9 @@ package formatting
 
 import (
 	"fmt"
+	"math"
+	"math/big"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -12,54 +15,278 @@ import (
 // This unit also exposes the [DurationUnit.RenderDurationProgress] method that
 // accepts [time.Duration] instead of [uint64]. This allows rendering negative
 // progress if required. For the individual unit version, use [Duration].
-type DurationUnit struct{}
+type DurationUnit struct {
+	// Format of the rendered durations. The zero value is [DurationClock],
+	// the format of [Duration].
+	Format DurationFormat
 
-func (*DurationUnit) Render(value uint64) string {
-	return Duration(time.Duration(value))
+	// Precision of the fractional seconds rendered (see
+	// [DurationFormat.FormatPrecision]). If zero,
+	// [DefaultDurationPrecision] is used.
+	Precision time.Duration
 }
 
-func (*DurationUnit) RenderProgress(current uint64, total uint64) string {
-	return fmt.Sprintf("%s / %s", Duration(time.Duration(current)), Duration(time.Duration(total)))
+func (u *DurationUnit) Render(value uint64) string {
+	return u.Format.FormatPrecision(time.Duration(value), u.Precision)
 }
 
-func (*DurationUnit) RenderDurationProgress(current time.Duration, total time.Duration) string {
-	return fmt.Sprintf("%s / %s", Duration(current), Duration(total))
+func (u *DurationUnit) RenderProgress(current uint64, total uint64) string {
+	return u.RenderDurationProgress(time.Duration(current), time.Duration(total))
+}
+
+// RenderRate renders the given rate of progress (in nanoseconds per second) as
+// a speed factor relative to real time (e.g., "1.5x" for 1.5 seconds per
+// second).
+func (*DurationUnit) RenderRate(perSecond float64) string {
+	return CurrentLocale().formatFloat(perSecond/float64(time.Second), 1) + "x"
+}
+
+// Parse the duration in s using [ParseDuration]. Negative durations cannot be
+// represented by the unit, so they are rejected.
+func (*DurationUnit) Parse(s string) (uint64, error) {
+	d, err := ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+
+	if d < 0 {
+		return 0, fmt.Errorf("%w: %q is negative", ErrRange, s)
+	}
+
+	return uint64(d), nil
+}
+
+func (u *DurationUnit) RenderDurationProgress(current time.Duration, total time.Duration) string {
+	return fmt.Sprintf("%s / %s", u.Format.FormatPrecision(current, u.Precision), u.Format.FormatPrecision(total, u.Precision))
 }
 
 // Duration formats the given value as a period of time, automatically including
 // or excluding precision as appropriate.
+//
+// Durations of a day or more are formatted with the number of days (e.g.,
+// "3d 01:00:00"). Durations of less than a minute are formatted with one
+// decimal place; for more (or less) sub-second precision, use
+// [DurationFormat.FormatPrecision].
+//
+// Fractional seconds are rendered with the decimal separator of the current
+// locale (see [SetLocale]).
 func Duration(d time.Duration) string {
-	var s strings.Builder
+	return formatClockDuration(d, DefaultDurationPrecision)
+}
 
-	// Handle negative durations by writing the minus sign and then treating the
-	// value as absolute.
-	if d < 0 {
-		s.WriteRune('-')
-		d = d.Abs()
+func formatClockDuration(d time.Duration, precision time.Duration) string {
+	sign, hours, minutes, seconds, nanoseconds := splitDuration(d)
+
+	switch {
+	case hours >= 24:
+		return fmt.Sprintf("%s%dd %02d:%02d:%02d", sign, hours/24, hours%24, minutes, seconds)
+	case hours > 0:
+		return fmt.Sprintf("%s%d:%02d:%02d", sign, hours, minutes, seconds)
+	case minutes > 0:
+		return fmt.Sprintf("%s%d:%02d", sign, minutes, seconds)
+	default:
+		return sign + formatSeconds(seconds, nanoseconds, precision) + "s"
+	}
+}
+
+// formatSeconds renders the given whole seconds and remaining nanoseconds with
+// the number of decimal places needed for the given precision.
+func formatSeconds(seconds uint64, nanoseconds uint64, precision time.Duration) string {
+	if precision <= 0 {
+		precision = DefaultDurationPrecision
+	}
+
+	// Count the decimal places needed to render multiples of the precision,
+	// rounding the precision down to a power of ten (e.g., 1ms needs three
+	// decimal places).
+	decimals := 0
+	for unit := time.Second; unit > precision && decimals < 9; unit /= 10 {
+		decimals++
+	}
+
+	fraction := nanoseconds / ipow(10, uint64(9-decimals))
+	return CurrentLocale().formatFixed(seconds, fraction, decimals)
+}
+
+// ParseDuration parses a duration formatted by [Duration], such as "4.2s",
+// "1:30" (minutes and seconds) or "1:02:03" (hours, minutes and seconds). The
+// seconds may include a fractional part (e.g., "1:30.5"), separated with the
+// decimal separator of the current locale (see [SetLocale]).
+//
+// A number without a unit is a number of seconds. Durations formatted with
+// any [DurationFormat] other than [DurationHumanized] (which is approximate)
+// are also accepted, such as "3d 1h" and "PT1H2M3.45S", as are durations
+// accepted by [time.ParseDuration] (such as "1h30m").
+func ParseDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+
+	unsigned, negative := strings.CutPrefix(s, "-")
+	seconds, ok := parseClockDuration(unsigned)
+	if !ok {
+		if iso, ok := strings.CutPrefix(unsigned, "PT"); ok {
+			return parseISO8601Duration(iso, negative, s)
+		}
+
+		return parseShortDuration(unsigned, negative, s)
+	}
+
+	nanoseconds, err := roundRat(seconds.Mul(seconds, big.NewRat(int64(time.Second), 1)), s)
+	if err != nil || nanoseconds > math.MaxInt64 {
+		return 0, fmt.Errorf("%w: %q", ErrRange, s)
+	}
+
+	if negative {
+		return -time.Duration(nanoseconds), nil
 	}
 
-	if hours := time.Duration(d.Hours()); hours > 0 {
-		s.WriteString(fmt.Sprintf("%d:", hours))
+	return time.Duration(nanoseconds), nil
+}
+
+// parseShortDuration parses an unsigned duration formatted with
+// [DurationShort] (e.g., "3d 1h 30m") or accepted by [time.ParseDuration].
+// The original input s is used in errors.
+func parseShortDuration(unsigned string, negative bool, s string) (time.Duration, error) {
+	unsigned = strings.ReplaceAll(unsigned, " ", "")
+
+	var days uint64
+	if before, after, ok := strings.Cut(unsigned, "d"); ok {
+		n, err := strconv.ParseUint(before, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %q is not a duration", ErrSyntax, s)
+		}
+
+		days, unsigned = n, after
 	}
 
-	if minutes := time.Duration(d.Minutes()); minutes > 0 {
-		minutesFmt := "%d:"
-		if d.Minutes() >= 60 {
-			minutesFmt = "%02d:"
+	var d time.Duration
+	if unsigned != "" || days == 0 {
+		var err error
+		d, err = time.ParseDuration(unsigned)
+		if err != nil || d < 0 {
+			return 0, fmt.Errorf("%w: %q is not a duration", ErrSyntax, s)
 		}
+	}
+
+	const day = 24 * time.Hour
+	if days > uint64(math.MaxInt64/day) || d > math.MaxInt64-time.Duration(days)*day {
+		return 0, fmt.Errorf("%w: %q", ErrRange, s)
+	}
+
+	d += time.Duration(days) * day
+	if negative {
+		return -d, nil
+	}
+
+	return d, nil
+}
+
+// parseISO8601Duration parses the part of an unsigned ISO 8601 duration after
+// "PT" (e.g., "1H2M3.45S"), as formatted with [DurationISO8601]. The original
+// input s is used in errors.
+func parseISO8601Duration(iso string, negative bool, s string) (time.Duration, error) {
+	// Convert the duration to the format of time.ParseDuration (e.g.,
+	// "1h2m3.45s"), requiring each component to be a number and to appear in
+	// order.
+	var compact strings.Builder
+	for _, designator := range []string{"H", "M", "S"} {
+		value, after, ok := strings.Cut(iso, designator)
+		if !ok {
+			continue
+		}
+
+		if value == "" || strings.Trim(value, "0123456789.") != "" {
+			return 0, fmt.Errorf("%w: %q is not a duration", ErrSyntax, s)
+		}
+
+		compact.WriteString(value + strings.ToLower(designator))
+		iso = after
+	}
+
+	if iso != "" || compact.Len() == 0 {
+		return 0, fmt.Errorf("%w: %q is not a duration", ErrSyntax, s)
+	}
+
+	d, err := time.ParseDuration(compact.String())
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrRange, s)
+	}
+
+	if negative {
+		return -d, nil
+	}
+
+	return d, nil
+}
+
+// parseClockDuration parses the number of seconds in an unsigned duration
+// formatted as a clock (e.g., "4.2s", "1:02:03" or "3d 01:00:00").
+func parseClockDuration(s string) (*big.Rat, bool) {
+	days := new(big.Rat)
+	if before, after, hasDays := strings.Cut(s, "d "); hasDays {
+		value, ok := parseClockPart(before, true, false)
+		if !ok {
+			return nil, false
+		}
+
+		// The clock after the days must include the hours, which must be
+		// less than a day.
+		parts := strings.Split(strings.TrimSpace(after), ":")
+		if len(parts) != 3 || len(parts[0]) != 2 || parts[0] >= "24" {
+			return nil, false
+		}
+
+		days, s = value, strings.TrimSpace(after)
+	}
+
+	parts := strings.Split(s, ":")
+	if len(parts) == 1 {
+		parts[0] = strings.TrimSuffix(parts[0], "s")
+	}
+
+	if len(parts) > 3 {
+		return nil, false
+	}
+
+	seconds := new(big.Rat).Mul(days, big.NewRat(24*60*60, 1))
+	clock := new(big.Rat)
+	for i, part := range parts {
+		if i == len(parts)-1 {
+			part = strings.Replace(part, CurrentLocale().decimalSeparator(), ".", 1)
+		}
+
+		value, ok := parseClockPart(part, i == 0, i == len(parts)-1)
+		if !ok {
+			return nil, false
+		}
+
+		clock.Mul(clock, big.NewRat(60, 1))
+		clock.Add(clock, value)
+	}
+
+	return seconds.Add(seconds, clock), true
+}
+
+// parseClockPart parses a part of a duration formatted as a clock (e.g., the
+// "02" in "1:02:03"). Only the last part (the seconds) may be fractional, and
+// every part other than the first must have two digits and be less than 60.
+func parseClockPart(part string, first bool, last bool) (*big.Rat, bool) {
+	whole, fraction, fractional := strings.Cut(part, ".")
+	if whole == "" || strings.Trim(whole, "0123456789") != "" || strings.Trim(fraction, "0123456789") != "" {
+		return nil, false
+	}
 
-		s.WriteString(fmt.Sprintf(minutesFmt, minutes%60))
+	if fractional && (!last || fraction == "") {
+		return nil, false
 	}
 
-	if seconds := time.Duration(d.Seconds()); seconds >= 10 {
-		s.WriteString(fmt.Sprintf("%02d", seconds%60))
-	} else {
-		s.WriteString(fmt.Sprintf("%d", seconds%60))
+	if !first && len(whole) != 2 {
+		return nil, false
 	}
 
-	if d.Seconds() < 60 {
-		s.WriteString(fmt.Sprintf(".%01ds", (time.Duration(d.Milliseconds())%1000)/100))
+	value, ok := new(big.Rat).SetString(part)
+	if !ok || (!first && value.Cmp(big.NewRat(60, 1)) >= 0) {
+		return nil, false
 	}
 
-	return s.String()
+	return value, true
 }
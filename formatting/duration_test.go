@@ -1,6 +1,7 @@
 package formatting_test
 
 import (
+	"math"
 	"testing"
 	"time"
 
@@ -12,20 +13,20 @@ func TestDurationUnit_Render(t *testing.T) {
 	// Smoke test that the rendering appears similar to TestDuration.
 	unit := &formatting.DurationUnit{}
 	assert.Equal(t, "1.2s", unit.Render(uint64((1*time.Second)+(200*time.Millisecond))))
-	assert.Equal(t, "24:00:00", unit.Render(uint64(24*time.Hour)))
+	assert.Equal(t, "1d 00:00:00", unit.Render(uint64(24*time.Hour)))
 }
 
 func TestDurationUnit_RenderProgress(t *testing.T) {
 	// Smoke test that the rendering appears similar to TestDuration.
 	unit := &formatting.DurationUnit{}
-	assert.Equal(t, "1.2s / 24:00:00", unit.RenderProgress(uint64((1*time.Second)+(200*time.Millisecond)), uint64(24*time.Hour)))
+	assert.Equal(t, "1.2s / 1d 00:00:00", unit.RenderProgress(uint64((1*time.Second)+(200*time.Millisecond)), uint64(24*time.Hour)))
 }
 
 func TestDurationUnit_RenderDurationProgress(t *testing.T) {
 	// Smoke test that the rendering appears similar to TestDuration.
 	unit := &formatting.DurationUnit{}
-	assert.Equal(t, "1.2s / 24:00:00", unit.RenderDurationProgress((1*time.Second)+(200*time.Millisecond), 24*time.Hour))
-	assert.Equal(t, "-1.2s / -24:00:00", unit.RenderDurationProgress(-((1*time.Second)+(200*time.Millisecond)), -24*time.Hour))
+	assert.Equal(t, "1.2s / 1d 00:00:00", unit.RenderDurationProgress((1*time.Second)+(200*time.Millisecond), 24*time.Hour))
+	assert.Equal(t, "-1.2s / -1d 00:00:00", unit.RenderDurationProgress(-((1*time.Second)+(200*time.Millisecond)), -24*time.Hour))
 }
 
 func TestDuration(t *testing.T) {
@@ -43,10 +44,142 @@ func TestDuration(t *testing.T) {
 	assert.Equal(t, "1:00:00", formatting.Duration(1*time.Hour))
 	assert.Equal(t, "5:00:00", formatting.Duration(5*time.Hour))
 	assert.Equal(t, "10:00:00", formatting.Duration(10*time.Hour))
-	assert.Equal(t, "24:00:00", formatting.Duration(24*time.Hour))
-	assert.Equal(t, "100:00:00", formatting.Duration(100*time.Hour))
+	assert.Equal(t, "1d 00:00:00", formatting.Duration(24*time.Hour))
+	assert.Equal(t, "4d 04:00:00", formatting.Duration(100*time.Hour))
 
 	assert.Equal(t, "-0.2s", formatting.Duration(-200*time.Millisecond))
 	assert.Equal(t, "-59.0s", formatting.Duration(-59*time.Second))
-	assert.Equal(t, "-100:00:00", formatting.Duration(-100*time.Hour))
+	assert.Equal(t, "-4d 04:00:00", formatting.Duration(-100*time.Hour))
+}
+
+func TestDuration_days(t *testing.T) {
+	assert.Equal(t, "23:59:59", formatting.Duration(24*time.Hour-time.Second))
+	assert.Equal(t, "1d 00:00:00", formatting.Duration(24*time.Hour))
+	assert.Equal(t, "3d 01:00:00", formatting.Duration(73*time.Hour))
+	assert.Equal(t, "3d 01:02:03", formatting.Duration(73*time.Hour+2*time.Minute+3*time.Second))
+	assert.Equal(t, "106751d 23:47:16", formatting.Duration(time.Duration(math.MaxInt64)))
+	assert.Equal(t, "-106751d 23:47:16", formatting.Duration(time.Duration(math.MinInt64)))
+}
+
+func TestDurationUnit_Precision(t *testing.T) {
+	d := 42*time.Millisecond + 123*time.Microsecond + 456*time.Nanosecond
+	for precision, expected := range map[time.Duration]string{
+		0:                      "0.0s",
+		time.Second:            "0s",
+		100 * time.Millisecond: "0.0s",
+		10 * time.Millisecond:  "0.04s",
+		time.Millisecond:       "0.042s",
+		250 * time.Microsecond: "0.0421s",
+		time.Microsecond:       "0.042123s",
+		time.Nanosecond:        "0.042123456s",
+	} {
+		unit := &formatting.DurationUnit{Precision: precision}
+		assert.Equal(t, expected, unit.Render(uint64(d)), precision)
+	}
+
+	// The precision only applies to durations rendered with fractional
+	// seconds.
+	unit := &formatting.DurationUnit{Precision: time.Millisecond}
+	assert.Equal(t, "1:00", unit.Render(uint64(time.Minute+d)))
+	assert.Equal(t, "0.042s / 1.000s", unit.RenderProgress(uint64(d), uint64(time.Second)))
+
+	unit = &formatting.DurationUnit{Format: formatting.DurationFixed, Precision: time.Millisecond}
+	assert.Equal(t, "00:01:00.042", unit.Render(uint64(time.Minute+d)))
+}
+
+func TestDurationUnit_Parse(t *testing.T) {
+	unit := &formatting.DurationUnit{}
+	value, err := unit.Parse("1:30")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(90*time.Second), value)
+
+	_, err = unit.Parse("-1.2s")
+	assert.ErrorIs(t, err, formatting.ErrRange)
+}
+
+func TestParseDuration(t *testing.T) {
+	for input, expected := range map[string]time.Duration{
+		"0":            0,
+		"0.0s":         0,
+		"4.2s":         4*time.Second + 200*time.Millisecond,
+		"90":           90 * time.Second,
+		"0.25":         250 * time.Millisecond,
+		"1:30":         90 * time.Second,
+		"1:30.5":       90*time.Second + 500*time.Millisecond,
+		"59:59":        59*time.Minute + 59*time.Second,
+		"90:00":        90 * time.Minute,
+		"1:02:03":      time.Hour + 2*time.Minute + 3*time.Second,
+		" 24:00:00 ":   24 * time.Hour,
+		"100:00:00":    100 * time.Hour,
+		"-0.2s":        -200 * time.Millisecond,
+		"-1:00:00":     -time.Hour,
+		"1h30m":        90 * time.Minute,
+		"-1m30s":       -90 * time.Second,
+		"1d 00:00:00":  24 * time.Hour,
+		"3d 01:02:03":  73*time.Hour + 2*time.Minute + 3*time.Second,
+		"-3d 01:00:00": -73 * time.Hour,
+		"0.042123s":    42123 * time.Microsecond,
+		"1d":           24 * time.Hour,
+		"3d 1h":        73 * time.Hour,
+		"1d 1h 30m":    25*time.Hour + 30*time.Minute,
+		"-3d 1h":       -73 * time.Hour,
+		"PT0S":         0,
+		"PT1H2M3.45S":  time.Hour + 2*time.Minute + 3450*time.Millisecond,
+		"PT100H":       100 * time.Hour,
+		"-PT1M":        -time.Minute,
+	} {
+		actual, err := formatting.ParseDuration(input)
+		if assert.NoError(t, err, input) {
+			assert.Equal(t, expected, actual, input)
+		}
+	}
+
+	for _, input := range []string{"", "s", "-", "1:", ":30", "1:3", "1:60", "1:2:3", "1.5:00", "1:00.", "1:00:00:00", "1m:30", "abc", "d", "1.5d", "1dd", "1d -1h", "PT", "PTS", "PT1S2H", "PT-1H", "P1D", "1d 1:00:00", "1d 24:00:00", "1d 01:00", "1.5d 01:00:00"} {
+		_, err := formatting.ParseDuration(input)
+		assert.ErrorIs(t, err, formatting.ErrSyntax, input)
+	}
+
+	_, err := formatting.ParseDuration("3000000:00:00")
+	assert.ErrorIs(t, err, formatting.ErrRange)
+}
+
+func TestParseDuration_roundTrip(t *testing.T) {
+	for _, d := range []time.Duration{
+		0,
+		200 * time.Millisecond,
+		time.Second + 200*time.Millisecond,
+		11*time.Second + 300*time.Millisecond,
+		59 * time.Second,
+		time.Minute,
+		59*time.Minute + 59*time.Second,
+		time.Hour,
+		time.Hour + 2*time.Minute + 3*time.Second,
+		24 * time.Hour,
+		100 * time.Hour,
+		-200 * time.Millisecond,
+		-100 * time.Hour,
+	} {
+		parsed, err := formatting.ParseDuration(formatting.Duration(d))
+		if assert.NoError(t, err, d) {
+			assert.Equal(t, d, parsed)
+		}
+	}
+
+	// Sweep through a range of durations (including those with precision that
+	// is lost when formatting) and check that formatting the parsed duration
+	// gives the same result.
+	for d := -time.Hour; d < 50*time.Hour; d += 7*time.Second + 321*time.Millisecond {
+		parsed, err := formatting.ParseDuration(formatting.Duration(d))
+		if assert.NoError(t, err, d) {
+			assert.Equal(t, formatting.Duration(d), formatting.Duration(parsed), d)
+		}
+	}
+
+	// Precision lost when formatting is not recovered, but formatting the
+	// parsed duration gives the same result.
+	d := time.Minute + 1500*time.Millisecond
+	parsed, err := formatting.ParseDuration(formatting.Duration(d))
+	assert.NoError(t, err)
+	assert.Equal(t, time.Minute+time.Second, parsed)
+	assert.Equal(t, formatting.Duration(d), formatting.Duration(parsed))
 }
@@ -0,0 +1,185 @@
+package formatting
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultDurationPrecision is the sub-second precision that durations are
+// formatted with by default (one decimal place).
+const DefaultDurationPrecision = 100 * time.Millisecond
+
+// DurationFormat is a style that durations can be formatted in.
+type DurationFormat int
+
+const (
+	// DurationClock formats durations like a clock, automatically including
+	// or excluding precision as appropriate (e.g., "4.2s", "1:30", "1:02:03"
+	// and "3d 01:00:00"). This is the format of [Duration].
+	DurationClock DurationFormat = iota
+
+	// DurationShort formats durations with a unit symbol for each non-zero
+	// component (e.g., "4.2s", "1h 2m 3s" and "3d 1h").
+	DurationShort
+
+	// DurationHumanized formats durations approximately, in words (e.g., "5
+	// seconds" and "about 5 minutes"), with negative durations in the past
+	// (e.g., "about 5 minutes ago"). The words and phrases are translated by
+	// the current locale (see [SetLocale], [Locale.Words] and
+	// [Locale.About]).
+	DurationHumanized
+
+	// DurationISO8601 formats durations as ISO 8601 durations with exact
+	// (fractional) seconds (e.g., "PT4.2S" and "PT1H2M3S"), for logs and
+	// machine-readable output.
+	DurationISO8601
+
+	// DurationFixed formats durations like a clock with every component
+	// included (e.g., "00:00:04.2" and "01:02:03.0"), so that durations of
+	// less than 100 hours have a fixed width.
+	DurationFixed
+)
+
+// Format the given duration in the style, with the default sub-second
+// precision. Negative durations are prefixed with a minus sign.
+func (f DurationFormat) Format(d time.Duration) string {
+	return f.FormatPrecision(d, DefaultDurationPrecision)
+}
+
+// FormatPrecision formats the given duration in the style, rendering
+// fractional seconds to the given precision (e.g., [time.Millisecond] for
+// three decimal places) where the style renders them. The precision is
+// rounded down to a power of ten, and if it is zero, the default precision
+// is used.
+//
+// Fractional seconds are truncated, rather than rounded. [DurationISO8601]
+// always renders exact fractional seconds.
+func (f DurationFormat) FormatPrecision(d time.Duration, precision time.Duration) string {
+	switch f {
+	case DurationShort:
+		return formatShortDuration(d, precision)
+	case DurationHumanized:
+		return formatHumanizedDuration(d)
+	case DurationISO8601:
+		return formatISO8601Duration(d)
+	case DurationFixed:
+		return formatFixedDuration(d, precision)
+	default:
+		return formatClockDuration(d, precision)
+	}
+}
+
+// splitDuration splits the absolute value of d into its whole hours, minutes
+// and seconds, and the remaining nanoseconds. The sign of d is returned
+// separately.
+func splitDuration(d time.Duration) (sign string, hours, minutes, seconds, nanoseconds uint64) {
+	abs := uint64(d)
+	if d < 0 {
+		sign = "-"
+		abs = -abs
+	}
+
+	nanoseconds = abs % uint64(time.Second)
+	seconds = abs / uint64(time.Second)
+	return sign, seconds / 3600, seconds / 60 % 60, seconds % 60, nanoseconds
+}
+
+func formatShortDuration(d time.Duration, precision time.Duration) string {
+	sign, hours, minutes, seconds, nanoseconds := splitDuration(d)
+	if hours == 0 && minutes == 0 {
+		return sign + formatSeconds(seconds, nanoseconds, precision) + "s"
+	}
+
+	var parts []string
+	for _, part := range []struct {
+		value  uint64
+		symbol string
+	}{
+		{hours / 24, "d"},
+		{hours % 24, "h"},
+		{minutes, "m"},
+		{seconds, "s"},
+	} {
+		if part.value > 0 {
+			parts = append(parts, strconv.FormatUint(part.value, 10)+part.symbol)
+		}
+	}
+
+	return sign + strings.Join(parts, " ")
+}
+
+func formatHumanizedDuration(d time.Duration) string {
+	l := CurrentLocale()
+	s := formatAbsHumanizedDuration(l, d.Abs())
+	if d < 0 {
+		return fmt.Sprintf(l.ago(), s)
+	}
+
+	return s
+}
+
+// formatAbsHumanizedDuration formats the non-negative duration d in words.
+func formatAbsHumanizedDuration(l *Locale, d time.Duration) string {
+	if d < time.Second {
+		return l.lessThanASecond()
+	}
+
+	if d < time.Minute {
+		seconds := uint64(d / time.Second)
+		return fmt.Sprintf("%s %s", l.formatUint(seconds), l.word("second", seconds))
+	}
+
+	// Longer durations are rounded to the nearest minute, hour or day, moving
+	// to the next unit if rounding reaches it (e.g., 59m45s is about 1 hour).
+	word, unit := "minute", time.Minute
+	if d.Round(time.Minute) >= time.Hour {
+		word, unit = "hour", time.Hour
+	}
+
+	if d.Round(time.Hour) >= 24*time.Hour {
+		word, unit = "day", 24*time.Hour
+	}
+
+	n := uint64(d.Round(unit) / unit)
+	return fmt.Sprintf(l.about(), fmt.Sprintf("%s %s", l.formatUint(n), l.word(word, n)))
+}
+
+func formatISO8601Duration(d time.Duration) string {
+	sign, hours, minutes, seconds, nanoseconds := splitDuration(d)
+
+	var s strings.Builder
+	s.WriteString(sign)
+	s.WriteString("PT")
+
+	if hours > 0 {
+		s.WriteString(fmt.Sprintf("%dH", hours))
+	}
+
+	if minutes > 0 {
+		s.WriteString(fmt.Sprintf("%dM", minutes))
+	}
+
+	if seconds > 0 || nanoseconds > 0 || (hours == 0 && minutes == 0) {
+		s.WriteString(strconv.FormatUint(seconds, 10))
+		if nanoseconds > 0 {
+			s.WriteString(strings.TrimRight(fmt.Sprintf(".%09d", nanoseconds), "0"))
+		}
+		s.WriteRune('S')
+	}
+
+	return s.String()
+}
+
+func formatFixedDuration(d time.Duration, precision time.Duration) string {
+	sign, hours, minutes, seconds, nanoseconds := splitDuration(d)
+
+	// Pad the seconds (but not their fractional part) to two digits.
+	padding := ""
+	if seconds < 10 {
+		padding = "0"
+	}
+
+	return fmt.Sprintf("%s%02d:%02d:%s%s", sign, hours, minutes, padding, formatSeconds(seconds, nanoseconds, precision))
+}
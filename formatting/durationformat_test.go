@@ -0,0 +1,137 @@
+package formatting_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apollosoftwarexyz/mon/formatting"
+	"github.com/stretchr/testify/assert"
+)
+
+const testDuration = time.Hour + 2*time.Minute + 3*time.Second + 450*time.Millisecond
+
+func TestDurationFormat_Format_clock(t *testing.T) {
+	assert.Equal(t, formatting.Duration(testDuration), formatting.DurationClock.Format(testDuration))
+	assert.Equal(t, "1:02:03", formatting.DurationClock.Format(testDuration))
+}
+
+func TestDurationFormat_Format_short(t *testing.T) {
+	f := formatting.DurationShort
+	assert.Equal(t, "0.0s", f.Format(0))
+	assert.Equal(t, "4.2s", f.Format(4*time.Second+250*time.Millisecond))
+	assert.Equal(t, "59.9s", f.Format(59*time.Second+999*time.Millisecond))
+	assert.Equal(t, "1m", f.Format(time.Minute))
+	assert.Equal(t, "1m 30s", f.Format(90*time.Second))
+	assert.Equal(t, "1h 2m 3s", f.Format(testDuration))
+	assert.Equal(t, "1h 3s", f.Format(time.Hour+3*time.Second))
+	assert.Equal(t, "4d 4h", f.Format(100*time.Hour))
+	assert.Equal(t, "-1h 2m 3s", f.Format(-testDuration))
+}
+
+func TestDurationFormat_Format_humanized(t *testing.T) {
+	f := formatting.DurationHumanized
+	assert.Equal(t, "less than a second", f.Format(0))
+	assert.Equal(t, "less than a second ago", f.Format(-999*time.Millisecond))
+	assert.Equal(t, "1 second", f.Format(time.Second))
+	assert.Equal(t, "59 seconds", f.Format(59*time.Second+900*time.Millisecond))
+	assert.Equal(t, "about 1 minute", f.Format(time.Minute))
+	assert.Equal(t, "about 5 minutes", f.Format(4*time.Minute+45*time.Second))
+	assert.Equal(t, "about 1 hour", f.Format(59*time.Minute+45*time.Second))
+	assert.Equal(t, "about 1 hour", f.Format(testDuration))
+	assert.Equal(t, "about 2 hours", f.Format(90*time.Minute))
+	assert.Equal(t, "about 1 day", f.Format(23*time.Hour+45*time.Minute))
+	assert.Equal(t, "about 3 days", f.Format(73*time.Hour))
+	assert.Equal(t, "about 5 minutes ago", f.Format(-5*time.Minute))
+	assert.Equal(t, "5 seconds ago", f.Format(-5*time.Second))
+
+	withLocale(t, formatting.German)
+	assert.Equal(t, "etwa 5 Minuten", f.Format(5*time.Minute))
+	assert.Equal(t, "etwa 5 Minuten her", f.Format(-5*time.Minute))
+	assert.Equal(t, "1 Sekunde", f.Format(time.Second))
+	assert.Equal(t, "etwa 3 Tage", f.Format(73*time.Hour))
+	assert.Equal(t, "weniger als eine Sekunde", f.Format(0))
+	assert.Equal(t, "weniger als eine Sekunde her", f.Format(-time.Millisecond))
+
+	// Phrases that are not translated are rendered in English.
+	withLocale(t, &formatting.Locale{
+		Words: map[string]formatting.Word{
+			"minute": {One: "Minute", Other: "Minuten"},
+		},
+		About: "etwa %s",
+	})
+	assert.Equal(t, "etwa 5 Minuten", f.Format(5*time.Minute))
+	assert.Equal(t, "etwa 5 Minuten ago", f.Format(-5*time.Minute))
+	assert.Equal(t, "less than a second", f.Format(0))
+}
+
+func TestDurationFormat_Format_iso8601(t *testing.T) {
+	f := formatting.DurationISO8601
+	assert.Equal(t, "PT0S", f.Format(0))
+	assert.Equal(t, "PT0.25S", f.Format(250*time.Millisecond))
+	assert.Equal(t, "PT0.000000001S", f.Format(1))
+	assert.Equal(t, "PT4.2S", f.Format(4*time.Second+200*time.Millisecond))
+	assert.Equal(t, "PT1M", f.Format(time.Minute))
+	assert.Equal(t, "PT1H2M3.45S", f.Format(testDuration))
+	assert.Equal(t, "PT100H", f.Format(100*time.Hour))
+	assert.Equal(t, "-PT1H", f.Format(-time.Hour))
+}
+
+func TestDurationFormat_Format_fixed(t *testing.T) {
+	f := formatting.DurationFixed
+	assert.Equal(t, "00:00:00.0", f.Format(0))
+	assert.Equal(t, "00:00:04.2", f.Format(4*time.Second+250*time.Millisecond))
+	assert.Equal(t, "01:02:03.4", f.Format(testDuration))
+	assert.Equal(t, "100:00:00.0", f.Format(100*time.Hour))
+	assert.Equal(t, "-01:02:03.4", f.Format(-testDuration))
+}
+
+func TestDurationUnit_Format(t *testing.T) {
+	unit := &formatting.DurationUnit{Format: formatting.DurationShort}
+	assert.Equal(t, "1h 2m 3s", unit.Render(uint64(testDuration)))
+	assert.Equal(t, "4.0s / 1h 2m 3s", unit.RenderProgress(uint64(4*time.Second), uint64(testDuration)))
+
+	// Durations formatted in the short format can be parsed.
+	d, err := unit.Parse(unit.Render(uint64(testDuration)))
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(testDuration.Truncate(time.Second)), d)
+}
+
+func TestDurationFormat_roundTrip(t *testing.T) {
+	formats := map[string]formatting.DurationFormat{
+		"clock":   formatting.DurationClock,
+		"short":   formatting.DurationShort,
+		"iso8601": formatting.DurationISO8601,
+		"fixed":   formatting.DurationFixed,
+	}
+
+	for name, f := range formats {
+		for _, d := range []time.Duration{
+			0,
+			4*time.Second + 200*time.Millisecond,
+			time.Minute,
+			time.Hour + 2*time.Minute + 3*time.Second,
+			24 * time.Hour,
+			73 * time.Hour,
+			25*time.Hour + 30*time.Minute,
+			-time.Hour - 2*time.Minute - 3*time.Second,
+		} {
+			parsed, err := formatting.ParseDuration(f.Format(d))
+			if assert.NoError(t, err, "%s: %s", name, f.Format(d)) {
+				assert.Equal(t, d, parsed, "%s: %s", name, f.Format(d))
+			}
+		}
+
+		// Precision lost when formatting is not recovered, but formatting
+		// the parsed duration gives the same result.
+		for d := -time.Hour; d < 50*time.Hour; d += 7*time.Second + 321*time.Millisecond {
+			parsed, err := formatting.ParseDuration(f.Format(d))
+			if assert.NoError(t, err, "%s: %s", name, f.Format(d)) {
+				assert.Equal(t, f.Format(d), f.Format(parsed), name)
+			}
+		}
+	}
+
+	// Humanized durations are approximate, so they cannot be parsed.
+	_, err := formatting.ParseDuration(formatting.DurationHumanized.Format(5 * time.Minute))
+	assert.ErrorIs(t, err, formatting.ErrSyntax)
+}
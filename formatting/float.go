@@ -0,0 +1,105 @@
+package formatting
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// maxAmountPrecision is the largest number of decimal places rendered for
+// fractional amounts by the built-in units.
+const maxAmountPrecision = 2
+
+// FloatUnit is implemented by units that can render fractional values (e.g.,
+// "2.5 / 10 steps"), for tasks measured in fractional amounts such as seconds
+// of media processed.
+type FloatUnit interface {
+	// RenderFloat renders the (possibly fractional) value according to the
+	// unit.
+	RenderFloat(value float64) string
+
+	// RenderFloatProgress renders the (possibly fractional) progress according
+	// to the unit, as [Unit.RenderProgress] does.
+	RenderFloatProgress(current float64, total float64) string
+}
+
+// RenderFloat renders the given (possibly fractional) value with the unit,
+// using [FloatUnit.RenderFloat] if the unit implements [FloatUnit].
+//
+// Otherwise, the value is truncated to a whole value and rendered with
+// [Unit.Render].
+func RenderFloat(unit Unit, value float64) string {
+	if unit, ok := unit.(FloatUnit); ok {
+		return unit.RenderFloat(value)
+	}
+
+	return unit.Render(truncateFloat(value))
+}
+
+// RenderFloatProgress renders the given (possibly fractional) progress with
+// the unit, using [FloatUnit.RenderFloatProgress] if the unit implements
+// [FloatUnit].
+//
+// Otherwise, the values are truncated to whole values (so that incomplete
+// progress is never rendered as complete) and rendered with
+// [Unit.RenderProgress].
+func RenderFloatProgress(unit Unit, current float64, total float64) string {
+	if unit, ok := unit.(FloatUnit); ok {
+		return unit.RenderFloatProgress(current, total)
+	}
+
+	return unit.RenderProgress(truncateFloat(current), truncateFloat(total))
+}
+
+// truncateFloat truncates the value to a whole value, clamped to the range of
+// uint64 (with NaN as zero).
+func truncateFloat(value float64) uint64 {
+	switch {
+	case !(value > 0):
+		return 0
+	case value >= math.MaxUint64:
+		return math.MaxUint64
+	default:
+		return uint64(value)
+	}
+}
+
+// isWhole reports whether the value is a whole value in the range of uint64.
+func isWhole(value float64) bool {
+	return value >= 0 && value < math.MaxUint64 && value == math.Trunc(value)
+}
+
+// formatAmount renders the (possibly fractional) value with up to two decimal
+// places, without trailing zeros (e.g., "2.5", rather than "2.50"). Whole
+// values are rendered exactly as [Locale.formatUint] renders them.
+//
+// The value is truncated, rather than rounded, so that incomplete progress is
+// never rendered as complete.
+func (l *Locale) formatAmount(value float64) string {
+	if !(value > 0) {
+		return l.formatUint(0)
+	}
+
+	whole, fraction, _ := strings.Cut(strconv.FormatFloat(value, 'f', -1, 64), ".")
+	n, err := strconv.ParseUint(whole, 10, 64)
+	if err != nil {
+		return whole
+	}
+
+	fraction = strings.TrimRight(fraction[:min(len(fraction), maxAmountPrecision)], "0")
+	if fraction == "" {
+		return l.formatUint(n)
+	}
+
+	return l.formatUint(n) + l.decimalSeparator() + fraction
+}
+
+// wordAmount returns the translation of the given singular English word for
+// the (possibly fractional) value.
+func (l *Locale) wordAmount(word string, value float64) string {
+	if value == 1 {
+		return l.word(word, 1)
+	}
+
+	return l.plural(word)
+}
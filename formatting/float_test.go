@@ -0,0 +1,96 @@
+package formatting_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/apollosoftwarexyz/mon/formatting"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderFloat(t *testing.T) {
+	assert.Equal(t, "2.5 steps", formatting.RenderFloat(&formatting.StepsUnit{}, 2.5))
+	assert.Equal(t, "1 step", formatting.RenderFloat(&formatting.StepsUnit{}, 1))
+
+	// Units that do not implement FloatUnit render the truncated value.
+	assert.Equal(t, "1.99k", formatting.RenderFloat(&formatting.CountUnit{}, 1999.9))
+	assert.Equal(t, "0", formatting.RenderFloat(&formatting.CountUnit{}, math.NaN()))
+	assert.Equal(t, "0", formatting.RenderFloat(&formatting.CountUnit{}, -1))
+}
+
+func TestRenderFloatProgress(t *testing.T) {
+	assert.Equal(t, "2.5 / 10 steps", formatting.RenderFloatProgress(&formatting.StepsUnit{}, 2.5, 10))
+
+	// Units that do not implement FloatUnit render the truncated values, so
+	// that incomplete progress is not rendered as complete.
+	assert.Equal(t, "2 / 3", formatting.RenderFloatProgress(&formatting.CountUnit{}, 2.5, 3))
+}
+
+func TestFloatUnit_whole(t *testing.T) {
+	// Whole values must render exactly as the integer variant does.
+	for _, unit := range []formatting.Unit{
+		&formatting.StepsUnit{},
+		&formatting.NounUnit{Singular: "file"},
+		&formatting.NounUnit{Singular: "file", Compact: true},
+		&formatting.PercentUnit{},
+		&formatting.RatioUnit{},
+		&formatting.BytesUnit{},
+		&formatting.BytesUnit{SI: true, Bits: true},
+	} {
+		for _, v := range []uint64{0, 1, 29, 100, 1234, 1234567} {
+			assert.Equal(t, unit.Render(v), formatting.RenderFloat(unit, float64(v)))
+			assert.Equal(t, unit.RenderProgress(v, 1234567), formatting.RenderFloatProgress(unit, float64(v), 1234567))
+		}
+	}
+}
+
+func TestStepsUnit_RenderFloat(t *testing.T) {
+	unit := &formatting.StepsUnit{}
+	assert.Equal(t, "0.5 steps", unit.RenderFloat(0.5))
+	assert.Equal(t, "1.33 steps", unit.RenderFloat(4.0/3))
+
+	// Values are truncated, rather than rounded.
+	assert.Equal(t, "9.99 steps", unit.RenderFloat(9.999))
+	assert.Equal(t, "9.99 / 10 steps", unit.RenderFloatProgress(9.999, 10))
+
+	withLocale(t, formatting.German)
+	assert.Equal(t, "1.234,5 Schritte", unit.RenderFloat(1234.5))
+}
+
+func TestBytesUnit_RenderFloat(t *testing.T) {
+	unit := &formatting.BytesUnit{}
+	assert.Equal(t, "1.500 MiB", unit.RenderFloat(1.5*float64(mebibyte)))
+	assert.Equal(t, "1.999 KiB", unit.RenderFloat(2047.5))
+	assert.Equal(t, "2 bytes / 3 bytes", unit.RenderFloatProgress(2.5, 3))
+
+	// Fractional bytes are rendered as whole bits.
+	unit = &formatting.BytesUnit{Bits: true}
+	assert.Equal(t, "20 bits", unit.RenderFloat(2.5))
+	assert.Equal(t, "1 bit", unit.RenderFloat(0.125))
+	assert.Equal(t, "127.999 Eib", unit.RenderFloat(math.MaxUint64))
+}
+
+func TestNounUnit_RenderFloat(t *testing.T) {
+	unit := &formatting.NounUnit{Singular: "second"}
+	assert.Equal(t, "12.5 seconds", unit.RenderFloat(12.5))
+	assert.Equal(t, "12.5 / 60 seconds", unit.RenderFloatProgress(12.5, 60))
+
+	unit = &formatting.NounUnit{Singular: "file", Compact: true}
+	assert.Equal(t, "1.23k / 12.5 files", unit.RenderFloatProgress(1234.5, 12.5))
+}
+
+func TestPercentUnit_RenderFloatProgress(t *testing.T) {
+	unit := &formatting.PercentUnit{}
+	assert.Equal(t, "0.0%", unit.RenderFloatProgress(0, 0))
+	assert.Equal(t, "33.3%", unit.RenderFloatProgress(1, 3))
+	assert.Equal(t, "50.0%", unit.RenderFloatProgress(1.25, 2.5))
+	assert.Equal(t, "99.9%", unit.RenderFloatProgress(2.4999, 2.5))
+	assert.Equal(t, "100.0%", unit.RenderFloatProgress(2.5, 2.5))
+	assert.Equal(t, "42.5%", unit.RenderFloat(42.5))
+}
+
+func TestRatioUnit_RenderFloatProgress(t *testing.T) {
+	unit := &formatting.RatioUnit{}
+	assert.Equal(t, "2.5 of 7", unit.RenderFloatProgress(2.5, 7))
+	assert.Equal(t, "2.5", unit.RenderFloat(2.5))
+}
@@ -0,0 +1,275 @@
+package formatting
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// Locale configures how the formatting functions render (and parse) numbers
+// and unit words.
+//
+// Unit symbols (such as "KiB" and "s") are not translated.
+type Locale struct {
+	// GroupSeparator is inserted between each group of three digits in whole
+	// numbers (e.g., the "," in "1,234,567"). If empty, digits are not
+	// grouped.
+	GroupSeparator string
+
+	// DecimalSeparator separates the whole and fractional parts of numbers
+	// (e.g., the "." in "1.5"). If empty, "." is used.
+	DecimalSeparator string
+
+	// Words translates the unit words rendered by this package, keyed by the
+	// singular English word (e.g., "step", "byte" or "bit"). Words that are
+	// not translated are rendered in English.
+	Words map[string]Word
+
+	// About formats an approximate duration, with "%s" replaced by the
+	// number and unit word (e.g., "about %s" for "about 5 minutes"). If
+	// empty, "about %s" is used.
+	About string
+
+	// LessThanASecond is rendered for approximate durations of less than a
+	// second. If empty, "less than a second" is used.
+	LessThanASecond string
+
+	// Ago formats a negative approximate duration, with "%s" replaced by the
+	// duration (e.g., "%s ago" for "about 5 minutes ago"). If empty, "%s
+	// ago" is used.
+	Ago string
+}
+
+// Word is the translation of a unit word.
+type Word struct {
+	// One is the singular form of the word, used when the value is one.
+	One string
+
+	// Other is the plural form of the word, used for any other value.
+	Other string
+}
+
+var (
+	// English is the default locale. Digits are not grouped, for consistency
+	// with [fmt].
+	English = &Locale{}
+
+	// German renders numbers such as "1.234.567,5" with translated unit words
+	// (e.g., "Schritte").
+	German = &Locale{
+		GroupSeparator:   ".",
+		DecimalSeparator: ",",
+		Words: map[string]Word{
+			"step": {One: "Schritt", Other: "Schritte"},
+			"byte": {One: "Byte", Other: "Byte"},
+			"bit":  {One: "Bit", Other: "Bit"},
+
+			"second": {One: "Sekunde", Other: "Sekunden"},
+			"minute": {One: "Minute", Other: "Minuten"},
+			"hour":   {One: "Stunde", Other: "Stunden"},
+			"day":    {One: "Tag", Other: "Tage"},
+		},
+		About:           "etwa %s",
+		LessThanASecond: "weniger als eine Sekunde",
+		Ago:             "%s her",
+	}
+)
+
+var locale atomic.Pointer[Locale]
+
+// SetLocale sets the locale used by the formatting functions (and so by the
+// built-in units) throughout the program. If l is nil, the [English] locale
+// is used.
+//
+// SetLocale is safe to call concurrently with formatting, but values that
+// have already been rendered are not updated.
+func SetLocale(l *Locale) {
+	locale.Store(l)
+}
+
+// CurrentLocale returns the locale set with [SetLocale] (or [English], by
+// default).
+func CurrentLocale() *Locale {
+	if l := locale.Load(); l != nil {
+		return l
+	}
+
+	return English
+}
+
+// decimalSeparator returns the decimal separator of the locale.
+func (l *Locale) decimalSeparator() string {
+	if l.DecimalSeparator == "" {
+		return "."
+	}
+
+	return l.DecimalSeparator
+}
+
+// about returns the pattern for approximate durations.
+func (l *Locale) about() string {
+	if l.About == "" {
+		return "about %s"
+	}
+
+	return l.About
+}
+
+// lessThanASecond returns the phrase for durations of less than a second.
+func (l *Locale) lessThanASecond() string {
+	if l.LessThanASecond == "" {
+		return "less than a second"
+	}
+
+	return l.LessThanASecond
+}
+
+// ago returns the pattern for negative durations.
+func (l *Locale) ago() string {
+	if l.Ago == "" {
+		return "%s ago"
+	}
+
+	return l.Ago
+}
+
+// formatUint renders n with its digits grouped.
+func (l *Locale) formatUint(n uint64) string {
+	digits := strconv.FormatUint(n, 10)
+	if l.GroupSeparator == "" || len(digits) <= 3 {
+		return digits
+	}
+
+	var s strings.Builder
+	for i, digit := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			s.WriteString(l.GroupSeparator)
+		}
+
+		s.WriteRune(digit)
+	}
+
+	return s.String()
+}
+
+// formatFixed renders a number with the given whole part and fractional part
+// (with the given number of decimal places).
+func (l *Locale) formatFixed(whole uint64, fraction uint64, precision int) string {
+	if precision <= 0 {
+		return l.formatUint(whole)
+	}
+
+	return l.formatUint(whole) + l.decimalSeparator() + strconv.FormatUint(fraction+ipow(10, uint64(precision)), 10)[1:]
+}
+
+// formatFloat renders the non-negative value v with the given number of
+// decimal places.
+func (l *Locale) formatFloat(v float64, precision int) string {
+	whole, fraction, _ := strings.Cut(strconv.FormatFloat(v, 'f', precision, 64), ".")
+	n, err := strconv.ParseUint(whole, 10, 64)
+	if err != nil {
+		return whole
+	}
+
+	if fraction == "" {
+		return l.formatUint(n)
+	}
+
+	return l.formatUint(n) + l.decimalSeparator() + fraction
+}
+
+// word returns the translation of the given singular English word for the
+// value n.
+func (l *Locale) word(word string, n uint64) string {
+	translation, ok := l.Words[word]
+	if !ok {
+		translation = Word{One: word, Other: word + "s"}
+	}
+
+	if n == 1 {
+		return translation.One
+	}
+
+	return translation.Other
+}
+
+// plural returns the plural translation of the given singular English word.
+func (l *Locale) plural(word string) string {
+	return l.word(word, 0)
+}
+
+// parseWord returns the singular English word that s is a form (either in
+// English or translated) of, or false if it is not a form of any of the
+// given words.
+func (l *Locale) parseWord(s string, words ...string) (string, bool) {
+	for _, word := range words {
+		translation := l.Words[word]
+		switch s {
+		case word, word + "s", translation.One, translation.Other:
+			if s != "" {
+				return word, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// splitNumber splits s into its leading (unsigned) number and the remainder
+// (including any whitespace between the two). The number is normalized so
+// that it has no group separators and uses "." as its decimal separator.
+//
+// Group separators are only accepted between groups of exactly three digits
+// in the whole part of the number (e.g., "1.234.567" in German). A group
+// separator that is not followed by a digit ends the number, but a malformed
+// group (e.g., "1.5" in German) is a syntax error rather than being silently
+// misread.
+func (l *Locale) splitNumber(s string) (number string, rest string, err error) {
+	var n strings.Builder
+
+	// digits is the number of digits in the current group of the whole part
+	// of the number, and grouped is true once a group separator is read.
+	digits := 0
+	grouped, fractional := false, false
+
+	i := 0
+loop:
+	for i < len(s) {
+		switch {
+		case s[i] >= '0' && s[i] <= '9':
+			n.WriteByte(s[i])
+			digits++
+			i++
+		case !fractional && strings.HasPrefix(s[i:], l.decimalSeparator()):
+			if grouped && digits != 3 {
+				break loop
+			}
+
+			n.WriteByte('.')
+			fractional = true
+			i += len(l.decimalSeparator())
+		case !fractional && l.GroupSeparator != "" && strings.HasPrefix(s[i:], l.GroupSeparator) && startsWithDigit(s[i+len(l.GroupSeparator):]):
+			if digits == 0 || digits > 3 || (grouped && digits != 3) {
+				break loop
+			}
+
+			grouped = true
+			digits = 0
+			i += len(l.GroupSeparator)
+		default:
+			break loop
+		}
+	}
+
+	if grouped && !fractional && digits != 3 {
+		return "", "", fmt.Errorf("%w: %q has a malformed digit group", ErrSyntax, s)
+	}
+
+	return n.String(), s[i:], nil
+}
+
+// startsWithDigit returns true if s starts with an ASCII digit.
+func startsWithDigit(s string) bool {
+	return s != "" && s[0] >= '0' && s[0] <= '9'
+}
@@ -0,0 +1,116 @@
+package formatting_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apollosoftwarexyz/mon/formatting"
+	"github.com/stretchr/testify/assert"
+)
+
+// withLocale sets the locale for the duration of the test.
+func withLocale(t *testing.T, l *formatting.Locale) {
+	formatting.SetLocale(l)
+	t.Cleanup(func() { formatting.SetLocale(nil) })
+}
+
+func TestSetLocale(t *testing.T) {
+	assert.Same(t, formatting.English, formatting.CurrentLocale())
+
+	withLocale(t, formatting.German)
+	assert.Same(t, formatting.German, formatting.CurrentLocale())
+
+	formatting.SetLocale(nil)
+	assert.Same(t, formatting.English, formatting.CurrentLocale())
+}
+
+func TestLocale_german(t *testing.T) {
+	withLocale(t, formatting.German)
+
+	assert.Equal(t, "1 Schritt", formatting.Steps(1))
+	assert.Equal(t, "1.234.567 Schritte", formatting.Steps(1234567))
+	assert.Equal(t, "1.024 / 2.048 Schritte", (&formatting.StepsUnit{}).RenderProgress(1024, 2048))
+
+	assert.Equal(t, "1 Byte", formatting.Bytes(1))
+	assert.Equal(t, "702 Byte", formatting.Bytes(702))
+	assert.Equal(t, "1.023,999 KiB", formatting.Bytes(mebibyte-1))
+	assert.Equal(t, "8 Bit", (&formatting.BytesUnit{Bits: true}).Render(1))
+
+	assert.Equal(t, "4,2s", formatting.Duration(4*time.Second+200*time.Millisecond))
+	assert.Equal(t, "1:00:00", formatting.Duration(time.Hour))
+}
+
+func TestLocale_custom(t *testing.T) {
+	withLocale(t, &formatting.Locale{
+		GroupSeparator: " ",
+		Words: map[string]formatting.Word{
+			"step": {One: "file", Other: "files"},
+		},
+	})
+
+	assert.Equal(t, "1 file", formatting.Steps(1))
+	assert.Equal(t, "12 345 files", formatting.Steps(12345))
+
+	// Words that are not translated are rendered in English.
+	assert.Equal(t, "999 bytes", formatting.Bytes(999))
+	assert.Equal(t, "1 023.999 KiB", formatting.Bytes(mebibyte-1))
+
+	// A group separator that is not followed by a digit ends the number.
+	steps, err := formatting.ParseSteps("12 files")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(12), steps)
+
+	steps, err = formatting.ParseSteps("12 345 files")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(12345), steps)
+}
+
+func TestLocale_parse(t *testing.T) {
+	withLocale(t, formatting.German)
+
+	steps, err := formatting.ParseSteps("1.234.567 Schritte")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1234567), steps)
+
+	// The English words are always accepted.
+	steps, err = formatting.ParseSteps("1 step")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), steps)
+
+	bytes, err := formatting.ParseBytes("1,5 MiB")
+	assert.NoError(t, err)
+	assert.Equal(t, mebibyte+mebibyte/2, bytes)
+
+	// Group separators must separate groups of three digits, so that a
+	// number with the wrong decimal separator is not misread.
+	for _, s := range []string{"1.5 GiB", "1.5", "12.34 KiB", "1.2345 MiB", "1.234.56 bytes"} {
+		_, err = formatting.ParseBytes(s)
+		assert.ErrorIs(t, err, formatting.ErrSyntax, s)
+	}
+
+	_, err = formatting.ParseSteps("1.5 Schritte")
+	assert.ErrorIs(t, err, formatting.ErrSyntax)
+
+	_, err = formatting.ParseCount("2.50k")
+	assert.ErrorIs(t, err, formatting.ErrSyntax)
+
+	bytes, err = formatting.ParseBytes("1.536,5 KiB")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1536*1024+512), bytes)
+
+	d, err := formatting.ParseDuration("1:30,5")
+	assert.NoError(t, err)
+	assert.Equal(t, 90*time.Second+500*time.Millisecond, d)
+
+	for _, value := range []uint64{0, 1, 702, mebibyte - 1, 1234567890} {
+		parsed, err := formatting.ParseSteps(formatting.Steps(value))
+		if assert.NoError(t, err) {
+			assert.Equal(t, value, parsed)
+		}
+
+		parsed, err = formatting.ParseBytes(formatting.Bytes(value))
+		if assert.NoError(t, err) {
+			assert.Equal(t, formatting.Bytes(value), formatting.Bytes(parsed))
+		}
+	}
+}
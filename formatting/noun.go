@@ -0,0 +1,126 @@
+package formatting
+
+import "fmt"
+
+// NounUnit renders discrete numbers of things named by a noun, such as files,
+// rows or requests (e.g., "1 file" and "42 files").
+//
+// Unlike the words rendered by [StepsUnit], the noun is not translated by the
+// current locale (see [SetLocale]), but numbers are still rendered according
+// to it.
+type NounUnit struct {
+	// Singular form of the noun (e.g., "file").
+	Singular string
+
+	// Plural form of the noun (e.g., "files"). If empty, "s" is appended to
+	// the singular form.
+	Plural string
+
+	// Abbreviation of the noun, used instead of the plural form when rendering
+	// rates (e.g., "req" in "12 req/s"). If empty, the plural form is used.
+	Abbreviation string
+
+	// Compact renders values of 1,000 or more with a metric prefix (e.g.,
+	// "1.23k files" rather than "1234 files"), as [Count] does.
+	Compact bool
+
+	// Figures is the number of significant figures that compacted values are
+	// rendered with (see [CountUnit.Figures]). If zero, three significant
+	// figures are rendered.
+	Figures int
+}
+
+// NewNounUnit returns a [NounUnit] for the noun with the given singular and
+// plural forms.
+func NewNounUnit(singular string, plural string) *NounUnit {
+	return &NounUnit{Singular: singular, Plural: plural}
+}
+
+func (n *NounUnit) Render(value uint64) string {
+	if value == 1 {
+		return fmt.Sprintf("%s %s", n.renderCount(value), n.Singular)
+	}
+
+	return fmt.Sprintf("%s %s", n.renderCount(value), n.plural())
+}
+
+func (n *NounUnit) RenderProgress(current uint64, total uint64) string {
+	return fmt.Sprintf("%s / %s %s", n.renderCount(current), n.renderCount(total), n.plural())
+}
+
+// RenderRate renders the given number of things per second (e.g., "12
+// files/s").
+func (n *NounUnit) RenderRate(perSecond float64) string {
+	name := n.Abbreviation
+	if name == "" {
+		name = n.plural()
+	}
+
+	l := CurrentLocale()
+	return formatRate(perSecond, func(value float64) string {
+		if n.Compact {
+			return fmt.Sprintf("%s %s", formatCountRateValue(l, value, countFigures(n.Figures)), name)
+		}
+
+		return fmt.Sprintf("%s %s", formatRateValue(l, value), name)
+	})
+}
+
+func (n *NounUnit) RenderFloat(value float64) string {
+	if value == 1 {
+		return fmt.Sprintf("%s %s", n.renderAmount(value), n.Singular)
+	}
+
+	return fmt.Sprintf("%s %s", n.renderAmount(value), n.plural())
+}
+
+func (n *NounUnit) RenderFloatProgress(current float64, total float64) string {
+	return fmt.Sprintf("%s / %s %s", n.renderAmount(current), n.renderAmount(total), n.plural())
+}
+
+// Parse a value rendered by the unit (e.g., "42 files" or, if compacted,
+// "1.23k files"). The noun may be omitted, and compacted values are accepted
+// even if the unit is not compacted.
+func (n *NounUnit) Parse(s string) (uint64, error) {
+	value, noun, err := parseCount(CurrentLocale(), s)
+	if err != nil {
+		return 0, err
+	}
+
+	switch noun {
+	case "", n.Singular, n.plural(), n.Abbreviation:
+		return value, nil
+	default:
+		return 0, fmt.Errorf("%w: %q has an unknown unit %q", ErrSyntax, s, noun)
+	}
+}
+
+// plural returns the plural form of the noun.
+func (n *NounUnit) plural() string {
+	if n.Plural == "" {
+		return n.Singular + "s"
+	}
+
+	return n.Plural
+}
+
+// renderCount renders the given count, compacting it if configured to.
+func (n *NounUnit) renderCount(value uint64) string {
+	l := CurrentLocale()
+	if n.Compact {
+		return compactCount(l, value, countFigures(n.Figures))
+	}
+
+	return l.formatUint(value)
+}
+
+// renderAmount renders the given (possibly fractional) amount, compacting it
+// if configured to and it is at least 1,000.
+func (n *NounUnit) renderAmount(value float64) string {
+	l := CurrentLocale()
+	if n.Compact && value >= 1000 {
+		return compactCount(l, truncateFloat(value), countFigures(n.Figures))
+	}
+
+	return l.formatAmount(value)
+}
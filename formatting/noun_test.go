@@ -0,0 +1,92 @@
+package formatting_test
+
+import (
+	"testing"
+
+	"github.com/apollosoftwarexyz/mon/formatting"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNounUnit_Render(t *testing.T) {
+	unit := formatting.NewNounUnit("file", "files")
+	assert.Equal(t, "0 files", unit.Render(0))
+	assert.Equal(t, "1 file", unit.Render(1))
+	assert.Equal(t, "1234567 files", unit.Render(1234567))
+
+	// The plural form defaults to the singular form with an "s".
+	unit = &formatting.NounUnit{Singular: "row"}
+	assert.Equal(t, "2 rows", unit.Render(2))
+
+	unit = &formatting.NounUnit{Singular: "query", Plural: "queries"}
+	assert.Equal(t, "2 queries", unit.Render(2))
+}
+
+func TestNounUnit_Render_compact(t *testing.T) {
+	unit := &formatting.NounUnit{Singular: "file", Compact: true}
+	assert.Equal(t, "1 file", unit.Render(1))
+	assert.Equal(t, "999 files", unit.Render(999))
+	assert.Equal(t, "1.00k files", unit.Render(1000))
+	assert.Equal(t, "1.23k files", unit.Render(1234))
+	assert.Equal(t, "12.3k files", unit.Render(12345))
+	assert.Equal(t, "999k files", unit.Render(999999))
+	assert.Equal(t, "1.20M files", unit.Render(1_200_000))
+	assert.Equal(t, "18.4E files", unit.Render(1<<64-1))
+}
+
+func TestNounUnit_RenderProgress(t *testing.T) {
+	unit := formatting.NewNounUnit("file", "files")
+	assert.Equal(t, "1 / 1 files", unit.RenderProgress(1, 1))
+	assert.Equal(t, "1234 / 5678 files", unit.RenderProgress(1234, 5678))
+
+	unit.Compact = true
+	assert.Equal(t, "1.23k / 5.67k files", unit.RenderProgress(1234, 5678))
+}
+
+func TestNounUnit_RenderRate(t *testing.T) {
+	unit := formatting.NewNounUnit("request", "requests")
+	assert.Equal(t, "5.0 requests/s", unit.RenderRate(5))
+	assert.Equal(t, "30 requests/min", unit.RenderRate(0.5))
+	assert.Equal(t, "12 requests/s", unit.RenderRate(12.3))
+	assert.Equal(t, "1235 requests/s", unit.RenderRate(1234.6))
+
+	unit.Abbreviation = "req"
+	unit.Compact = true
+	assert.Equal(t, "1.23k req/s", unit.RenderRate(1234.5))
+
+	// Rates are rounded before choosing whether to compact them.
+	assert.Equal(t, "999 req/s", unit.RenderRate(999.4))
+	assert.Equal(t, "1.00k req/s", unit.RenderRate(999.96))
+}
+
+func TestNounUnit_Parse(t *testing.T) {
+	unit := &formatting.NounUnit{Singular: "request", Abbreviation: "req"}
+	for input, expected := range map[string]uint64{
+		"0":              0,
+		"1 request":      1,
+		"42 requests":    42,
+		"42 req":         42,
+		"1.23k requests": 1230,
+		"12.3M":          12_300_000,
+		"1k":             1000,
+	} {
+		actual, err := unit.Parse(input)
+		if assert.NoError(t, err, input) {
+			assert.Equal(t, expected, actual, input)
+		}
+	}
+
+	for _, input := range []string{"", "requests", "42 files", "5 kilorequests", "5krequests"} {
+		_, err := unit.Parse(input)
+		assert.ErrorIs(t, err, formatting.ErrSyntax, input)
+	}
+
+	// Compacted values are truncated, so parsing recovers a value that
+	// renders the same.
+	unit.Compact = true
+	for _, value := range []uint64{0, 1, 999, 1234, 12345, 999999, 1 << 40} {
+		parsed, err := unit.Parse(unit.Render(value))
+		if assert.NoError(t, err) {
+			assert.Equal(t, unit.Render(value), unit.Render(parsed))
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package formatting
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+var (
+	// ErrSyntax indicates that a value could not be parsed because it is not
+	// in a recognized format.
+	ErrSyntax = errors.New("formatting: invalid syntax")
+
+	// ErrRange indicates that a parsed value is out of range.
+	ErrRange = errors.New("formatting: value out of range")
+)
+
+// roundRat rounds the given non-negative value half-up to the nearest
+// [uint64], returning an error wrapping [ErrRange] (mentioning s) if it does
+// not fit.
+func roundRat(value *big.Rat, s string) (uint64, error) {
+	// floor(value + 1/2) = floor((2*num + den) / (2*den))
+	num := new(big.Int).Mul(value.Num(), big.NewInt(2))
+	num.Add(num, value.Denom())
+	den := new(big.Int).Mul(value.Denom(), big.NewInt(2))
+	rounded := num.Quo(num, den)
+
+	if !rounded.IsUint64() {
+		return 0, fmt.Errorf("%w: %q", ErrRange, s)
+	}
+
+	return rounded.Uint64(), nil
+}
+
+// parseNumber parses a number normalized by [Locale.splitNumber], rounded
+// half-up to the nearest [uint64]. The original input s is used in errors.
+func parseNumber(number string, s string) (uint64, error) {
+	value, ok := new(big.Rat).SetString(number)
+	if !ok {
+		return 0, fmt.Errorf("%w: %q is not a number", ErrSyntax, s)
+	}
+
+	return roundRat(value, s)
+}
@@ -0,0 +1,183 @@
+package formatting
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+)
+
+// defaultPercentPrecision is the number of decimal places rendered by
+// [PercentUnit] when [PercentUnit.Precision] is zero.
+const defaultPercentPrecision = 1
+
+// maxPercentPrecision is the largest number of decimal places rendered by
+// [PercentUnit].
+const maxPercentPrecision = 9
+
+// PercentUnit renders progress as a percentage (e.g., "42.5%"), for tasks
+// where only the fraction of work completed is known.
+//
+// Individual values (and rates) are rendered as percentage points, as if the
+// total were 100. This suits tasks whose progress is reported as a
+// percentage, with a total of 100 steps.
+type PercentUnit struct {
+	// Precision is the number of decimal places rendered (up to 9). If zero,
+	// one decimal place is rendered. If negative, only whole percentages are
+	// rendered.
+	Precision int
+}
+
+func (p *PercentUnit) Render(value uint64) string {
+	return CurrentLocale().formatUint(value) + "%"
+}
+
+// RenderProgress renders the percentage of the total that current is.
+//
+// The percentage is truncated, rather than rounded, so that incomplete
+// progress is never rendered as 100%.
+func (p *PercentUnit) RenderProgress(current uint64, total uint64) string {
+	precision := p.precision()
+
+	if total == 0 {
+		return CurrentLocale().formatFixed(0, 0, precision) + "%"
+	}
+
+	// Compute floor(current * 100 * 10^precision / total) exactly, which may
+	// not fit in 64 bits.
+	scale := ipow(10, uint64(precision))
+	scaled := new(big.Int).SetUint64(current)
+	scaled.Mul(scaled, new(big.Int).SetUint64(100*scale))
+	scaled.Quo(scaled, new(big.Int).SetUint64(total))
+
+	whole, fraction := new(big.Int).QuoRem(scaled, new(big.Int).SetUint64(scale), new(big.Int))
+	if !whole.IsUint64() {
+		return whole.String() + "%"
+	}
+
+	return CurrentLocale().formatFixed(whole.Uint64(), fraction.Uint64(), precision) + "%"
+}
+
+func (p *PercentUnit) RenderFloat(value float64) string {
+	return CurrentLocale().formatAmount(value) + "%"
+}
+
+// RenderFloatProgress renders the percentage of the total that current is,
+// truncated as [PercentUnit.RenderProgress] does.
+func (p *PercentUnit) RenderFloatProgress(current float64, total float64) string {
+	precision := p.precision()
+
+	if !(total > 0) || !(current > 0) {
+		return CurrentLocale().formatFixed(0, 0, precision) + "%"
+	}
+
+	// Whole values are rendered exactly, as floating point division may
+	// truncate, for example, 29% to 28.9%.
+	if isWhole(current) && isWhole(total) {
+		return p.RenderProgress(uint64(current), uint64(total))
+	}
+
+	scale := math.Pow10(precision)
+	scaled := math.Floor(current / total * 100 * scale)
+
+	// Never round incomplete progress up to 100%.
+	if current < total && scaled >= 100*scale {
+		scaled = 100*scale - 1
+	}
+
+	return CurrentLocale().formatFloat(scaled/scale, precision) + "%"
+}
+
+// Parse a percentage rendered by [PercentUnit.Render] (e.g., "42%"), as a
+// number of percentage points. The "%" may be omitted, and fractional
+// percentages are rounded to the nearest whole percentage.
+func (p *PercentUnit) Parse(s string) (uint64, error) {
+	number, rest, err := CurrentLocale().splitNumber(strings.TrimSpace(s))
+	if err != nil {
+		return 0, err
+	}
+
+	if rest = strings.TrimSpace(rest); rest != "" && rest != "%" {
+		return 0, fmt.Errorf("%w: %q has an unknown unit %q", ErrSyntax, s, rest)
+	}
+
+	return parseNumber(number, s)
+}
+
+// precision returns the number of decimal places for the unit's
+// configuration.
+func (p *PercentUnit) precision() int {
+	precision := p.Precision
+	if precision == 0 {
+		precision = defaultPercentPrecision
+	}
+
+	return min(max(precision, 0), maxPercentPrecision)
+}
+
+// RenderRate renders the given rate in percentage points per second (e.g.,
+// "1.5%/s").
+func (p *PercentUnit) RenderRate(perSecond float64) string {
+	l := CurrentLocale()
+	return formatRate(perSecond, func(value float64) string {
+		return formatRateValue(l, value) + "%"
+	})
+}
+
+// RatioUnit renders progress as a ratio of the completed values to the total
+// (e.g., "3 of 7").
+type RatioUnit struct {
+	// Separator between the completed values and the total. If empty, "of" is
+	// used.
+	Separator string
+}
+
+func (r *RatioUnit) Render(value uint64) string {
+	return CurrentLocale().formatUint(value)
+}
+
+func (r *RatioUnit) RenderProgress(current uint64, total uint64) string {
+	separator := r.Separator
+	if separator == "" {
+		separator = "of"
+	}
+
+	l := CurrentLocale()
+	return fmt.Sprintf("%s %s %s", l.formatUint(current), separator, l.formatUint(total))
+}
+
+func (r *RatioUnit) RenderFloat(value float64) string {
+	return CurrentLocale().formatAmount(value)
+}
+
+func (r *RatioUnit) RenderFloatProgress(current float64, total float64) string {
+	separator := r.Separator
+	if separator == "" {
+		separator = "of"
+	}
+
+	l := CurrentLocale()
+	return fmt.Sprintf("%s %s %s", l.formatAmount(current), separator, l.formatAmount(total))
+}
+
+func (r *RatioUnit) RenderRate(perSecond float64) string {
+	l := CurrentLocale()
+	return formatRate(perSecond, func(value float64) string {
+		return formatRateValue(l, value)
+	})
+}
+
+// Parse a number rendered by [RatioUnit.Render] (e.g., "3"). Fractional
+// numbers (e.g., "0.42") are rounded to the nearest whole number.
+func (r *RatioUnit) Parse(s string) (uint64, error) {
+	number, rest, err := CurrentLocale().splitNumber(strings.TrimSpace(s))
+	if err != nil {
+		return 0, err
+	}
+
+	if rest = strings.TrimSpace(rest); rest != "" {
+		return 0, fmt.Errorf("%w: %q has an unknown unit %q", ErrSyntax, s, rest)
+	}
+
+	return parseNumber(number, s)
+}
@@ -0,0 +1,122 @@
+package formatting_test
+
+import (
+	"testing"
+
+	"github.com/apollosoftwarexyz/mon/formatting"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPercentUnit_Render(t *testing.T) {
+	unit := &formatting.PercentUnit{}
+	assert.Equal(t, "0%", unit.Render(0))
+	assert.Equal(t, "42%", unit.Render(42))
+}
+
+func TestPercentUnit_RenderProgress(t *testing.T) {
+	unit := &formatting.PercentUnit{}
+	assert.Equal(t, "0.0%", unit.RenderProgress(0, 0))
+	assert.Equal(t, "0.0%", unit.RenderProgress(0, 7))
+	assert.Equal(t, "42.5%", unit.RenderProgress(425, 1000))
+	assert.Equal(t, "29.0%", unit.RenderProgress(29, 100))
+	assert.Equal(t, "42.8%", unit.RenderProgress(3, 7))
+	assert.Equal(t, "99.9%", unit.RenderProgress(9999, 10000))
+	assert.Equal(t, "100.0%", unit.RenderProgress(7, 7))
+	assert.Equal(t, "200.0%", unit.RenderProgress(14, 7))
+	assert.Equal(t, "100.0%", unit.RenderProgress(1<<64-1, 1<<64-1))
+
+	unit = &formatting.PercentUnit{Precision: 3}
+	assert.Equal(t, "42.857%", unit.RenderProgress(3, 7))
+
+	unit = &formatting.PercentUnit{Precision: -1}
+	assert.Equal(t, "42%", unit.RenderProgress(3, 7))
+	assert.Equal(t, "99%", unit.RenderProgress(9999, 10000))
+}
+
+func TestPercentUnit_RenderRate(t *testing.T) {
+	unit := &formatting.PercentUnit{}
+	assert.Equal(t, "1.5%/s", unit.RenderRate(1.5))
+	assert.Equal(t, "30%/min", unit.RenderRate(0.5))
+}
+
+func TestPercentUnit_Parse(t *testing.T) {
+	unit := &formatting.PercentUnit{}
+	var _ formatting.Parser = unit
+	for input, expected := range map[string]uint64{
+		"42%":   42,
+		"0%":    0,
+		"42":    42,
+		" 42 %": 42,
+		"42.5%": 43,
+		"200%":  200,
+	} {
+		actual, err := unit.Parse(input)
+		if assert.NoError(t, err, input) {
+			assert.Equal(t, expected, actual, input)
+		}
+	}
+
+	for _, input := range []string{"", "%", "-5%", "42 percent", "42%%"} {
+		_, err := unit.Parse(input)
+		assert.ErrorIs(t, err, formatting.ErrSyntax, input)
+	}
+
+	for _, value := range []uint64{0, 42, 100, 1234} {
+		parsed, err := unit.Parse(unit.Render(value))
+		if assert.NoError(t, err, value) {
+			assert.Equal(t, value, parsed)
+		}
+	}
+
+	withLocale(t, formatting.German)
+	actual, err := unit.Parse("1.234,5%")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1235), actual)
+}
+
+func TestRatioUnit_Render(t *testing.T) {
+	unit := &formatting.RatioUnit{}
+	assert.Equal(t, "3", unit.Render(3))
+}
+
+func TestRatioUnit_RenderProgress(t *testing.T) {
+	unit := &formatting.RatioUnit{}
+	assert.Equal(t, "3 of 7", unit.RenderProgress(3, 7))
+	assert.Equal(t, "0 of 0", unit.RenderProgress(0, 0))
+
+	unit = &formatting.RatioUnit{Separator: "/"}
+	assert.Equal(t, "3 / 7", unit.RenderProgress(3, 7))
+}
+
+func TestRatioUnit_RenderRate(t *testing.T) {
+	unit := &formatting.RatioUnit{}
+	assert.Equal(t, "2.5/s", unit.RenderRate(2.5))
+}
+
+func TestRatioUnit_Parse(t *testing.T) {
+	unit := &formatting.RatioUnit{}
+	var _ formatting.Parser = unit
+	for input, expected := range map[string]uint64{
+		"3":    3,
+		" 7 ":  7,
+		"0.42": 0,
+		"2.5":  3,
+	} {
+		actual, err := unit.Parse(input)
+		if assert.NoError(t, err, input) {
+			assert.Equal(t, expected, actual, input)
+		}
+	}
+
+	for _, input := range []string{"", "-3", "3 of 7", "3%"} {
+		_, err := unit.Parse(input)
+		assert.ErrorIs(t, err, formatting.ErrSyntax, input)
+	}
+
+	for _, value := range []uint64{0, 3, 1234567} {
+		parsed, err := unit.Parse(unit.Render(value))
+		if assert.NoError(t, err, value) {
+			assert.Equal(t, value, parsed)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package formatting
+
+import (
+	"fmt"
+	"math"
+)
+
+// RateUnit is implemented by units that can render the rate at which values
+// progress (e.g., "3.50 MiB/s").
+type RateUnit interface {
+	// RenderRate renders the given rate of progress, in values per second.
+	//
+	// Slow rates may be rendered per minute or per hour instead (e.g., "30
+	// steps/min" rather than "0.5 steps/s").
+	RenderRate(perSecond float64) string
+}
+
+// RenderRate renders the given rate of progress (in values per second) with
+// the unit, using [RateUnit.RenderRate] if the unit implements [RateUnit].
+//
+// Otherwise, the rate is rounded to a whole value, rendered with
+// [Unit.Render] and suffixed with the interval (e.g., "3 things/s").
+func RenderRate(unit Unit, perSecond float64) string {
+	if unit, ok := unit.(RateUnit); ok {
+		return unit.RenderRate(perSecond)
+	}
+
+	return formatRate(perSecond, func(value float64) string {
+		return unit.Render(uint64(math.Round(value)))
+	})
+}
+
+// formatRate renders the given rate (in values per second) with render,
+// switching to a per-minute or per-hour rate if there is less than one value
+// per second or minute respectively, and appends the interval.
+func formatRate(perSecond float64, render func(value float64) string) string {
+	value, interval := perSecond, "s"
+	if value > 0 && value < 1 {
+		value, interval = value*60, "min"
+	}
+
+	if value > 0 && value < 1 {
+		value, interval = value*60, "h"
+	}
+
+	return fmt.Sprintf("%s/%s", render(value), interval)
+}
+
+// formatRateValue renders a (non-negative) rate with one decimal place if it
+// is less than ten (once rounded to one decimal place), or as a whole number
+// otherwise.
+func formatRateValue(l *Locale, value float64) string {
+	if math.Round(value*10) < 100 {
+		return l.formatFloat(value, 1)
+	}
+
+	return l.formatFloat(value, 0)
+}
+
+// formatCountRateValue renders a (non-negative) rate of discrete values as
+// [formatRateValue] does, or compacted with a metric prefix (e.g., "1.00k")
+// if it rounds to 1,000 or more.
+func formatCountRateValue(l *Locale, value float64, figures int) string {
+	if rounded := math.Round(value); rounded >= 1000 {
+		return compactCount(l, truncateFloat(rounded), figures)
+	}
+
+	return formatRateValue(l, value)
+}
@@ -0,0 +1,81 @@
+package formatting_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apollosoftwarexyz/mon/formatting"
+	"github.com/stretchr/testify/assert"
+)
+
+// plainUnit is a [formatting.Unit] that does not implement
+// [formatting.RateUnit].
+type plainUnit struct{}
+
+func (plainUnit) Render(value uint64) string { return formatting.Steps(value) }
+
+func (plainUnit) RenderProgress(current uint64, total uint64) string {
+	return formatting.Steps(current) + " / " + formatting.Steps(total)
+}
+
+func TestRenderRate(t *testing.T) {
+	// Units that implement RateUnit render their own rates.
+	assert.Equal(t, "2.5 steps/s", formatting.RenderRate(&formatting.StepsUnit{}, 2.5))
+
+	// Other units are rendered with a whole value.
+	assert.Equal(t, "3 steps/s", formatting.RenderRate(plainUnit{}, 2.5))
+	assert.Equal(t, "1 step/s", formatting.RenderRate(plainUnit{}, 1))
+	assert.Equal(t, "30 steps/min", formatting.RenderRate(plainUnit{}, 0.5))
+}
+
+func TestRenderRate_slow(t *testing.T) {
+	unit := &formatting.StepsUnit{}
+	assert.Equal(t, "0.0 steps/s", unit.RenderRate(0))
+	assert.Equal(t, "1.0 steps/s", unit.RenderRate(1))
+	assert.Equal(t, "30 steps/min", unit.RenderRate(0.5))
+	assert.Equal(t, "1.0 steps/min", unit.RenderRate(1.0/60))
+	assert.Equal(t, "30 steps/h", unit.RenderRate(1.0/120))
+	assert.Equal(t, "0.1 steps/h", unit.RenderRate(1.0/36000))
+}
+
+func TestStepsUnit_RenderRate(t *testing.T) {
+	unit := &formatting.StepsUnit{}
+	assert.Equal(t, "2.5 steps/s", unit.RenderRate(2.5))
+	assert.Equal(t, "1235 steps/s", unit.RenderRate(1234.6))
+}
+
+func TestBytesUnit_RenderRate(t *testing.T) {
+	unit := &formatting.BytesUnit{}
+	assert.Equal(t, "3.500 KiB/s", unit.RenderRate(3.5*float64(kibibyte)))
+	assert.Equal(t, "512 bytes/s", unit.RenderRate(512))
+	assert.Equal(t, "30 bytes/min", unit.RenderRate(0.5))
+
+	unit = &formatting.BytesUnit{SI: true, Bits: true, Precision: 1}
+	assert.Equal(t, "12.5 Mb/s", unit.RenderRate(1_562_500))
+}
+
+func TestDurationUnit_RenderRate(t *testing.T) {
+	unit := &formatting.DurationUnit{}
+	assert.Equal(t, "1.0x", unit.RenderRate(float64(time.Second)))
+	assert.Equal(t, "1.5x", unit.RenderRate(1.5*float64(time.Second)))
+	assert.Equal(t, "0.5x", unit.RenderRate(0.5*float64(time.Second)))
+}
+
+func TestCountUnit_RenderRate(t *testing.T) {
+	unit := &formatting.CountUnit{}
+	assert.Equal(t, "12/s", unit.RenderRate(12))
+	assert.Equal(t, "12.3k/s", unit.RenderRate(12_345))
+	assert.Equal(t, "30/min", unit.RenderRate(0.5))
+
+	// Rates are rounded before choosing how to render them.
+	assert.Equal(t, "999/s", unit.RenderRate(999.4))
+	assert.Equal(t, "1.00k/s", unit.RenderRate(999.96))
+	assert.Equal(t, "9.9/s", unit.RenderRate(9.94))
+	assert.Equal(t, "10/s", unit.RenderRate(9.96))
+}
+
+func TestLocale_rate(t *testing.T) {
+	withLocale(t, formatting.German)
+	assert.Equal(t, "2,5 Schritte/s", (&formatting.StepsUnit{}).RenderRate(2.5))
+	assert.Equal(t, "1,5x", (&formatting.DurationUnit{}).RenderRate(1.5*float64(time.Second)))
+}
@@ -1,6 +1,11 @@
 package formatting
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
 
 // StepsUnit renders discrete numbers of steps.
 //
@@ -13,17 +18,68 @@ func (*StepsUnit) Render(value uint64) string {
 }
 
 func (*StepsUnit) RenderProgress(current uint64, total uint64) string {
-	return fmt.Sprintf("%d / %d steps", current, total)
+	l := CurrentLocale()
+	return fmt.Sprintf("%s / %s %s", l.formatUint(current), l.formatUint(total), l.plural("step"))
+}
+
+func (*StepsUnit) RenderRate(perSecond float64) string {
+	l := CurrentLocale()
+	return formatRate(perSecond, func(value float64) string {
+		return fmt.Sprintf("%s %s", formatRateValue(l, value), l.plural("step"))
+	})
+}
+
+func (*StepsUnit) RenderFloat(value float64) string {
+	l := CurrentLocale()
+	return fmt.Sprintf("%s %s", l.formatAmount(value), l.wordAmount("step", value))
+}
+
+func (*StepsUnit) RenderFloatProgress(current float64, total float64) string {
+	l := CurrentLocale()
+	return fmt.Sprintf("%s / %s %s", l.formatAmount(current), l.formatAmount(total), l.plural("step"))
+}
+
+// Parse the number of steps in s using [ParseSteps]. The word "steps" may be
+// omitted.
+func (*StepsUnit) Parse(s string) (uint64, error) {
+	return ParseSteps(s)
 }
 
 // Steps formats the given value as a discrete number of steps.
 //
-// If value is equal to one, the hardcoded string "1 step" is returned instead
-// for readability.
+// If value is equal to one, the singular "1 step" is returned instead for
+// readability. The number and word are rendered according to the current
+// locale (see [SetLocale]).
 func Steps(value uint64) string {
-	if value == 1 {
-		return "1 step"
+	l := CurrentLocale()
+	return fmt.Sprintf("%s %s", l.formatUint(value), l.word("step", value))
+}
+
+// ParseSteps parses a number of steps formatted by [Steps] (e.g., "5 steps"),
+// or a number without the unit.
+//
+// Numbers and words are parsed according to the current locale (see
+// [SetLocale]), although the English words are always accepted.
+func ParseSteps(s string) (uint64, error) {
+	l := CurrentLocale()
+	number, unit, err := l.splitNumber(strings.TrimSpace(s))
+	if err != nil {
+		return 0, err
+	}
+
+	unit = strings.TrimSpace(unit)
+	if _, ok := l.parseWord(unit, "step"); unit != "" && !ok {
+		return 0, fmt.Errorf("%w: %q has an unknown unit %q", ErrSyntax, s, unit)
+	}
+
+	value, err := strconv.ParseUint(number, 10, 64)
+	if err != nil {
+		if errors.Is(err, strconv.ErrRange) {
+			return 0, fmt.Errorf("%w: %q", ErrRange, s)
+		}
+
+		return 0, fmt.Errorf("%w: %q is not a number of steps", ErrSyntax, s)
 	}
 
-	return fmt.Sprintf("%d steps", value)
+	return value, nil
 }
@@ -34,3 +34,31 @@ func TestSteps(t *testing.T) {
 	assert.Equal(t, "2 steps", formatting.Steps(2))
 	assert.Equal(t, "1024 steps", formatting.Steps(1024))
 }
+
+func TestStepsUnit_Parse(t *testing.T) {
+	var unit formatting.Parser = &formatting.StepsUnit{}
+	value, err := unit.Parse("5 steps")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(5), value)
+}
+
+func TestParseSteps(t *testing.T) {
+	for _, value := range []uint64{0, 1, 2, 1024, 1<<64 - 1} {
+		parsed, err := formatting.ParseSteps(formatting.Steps(value))
+		if assert.NoError(t, err) {
+			assert.Equal(t, value, parsed)
+		}
+	}
+
+	value, err := formatting.ParseSteps("42")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(42), value)
+
+	for _, input := range []string{"", "steps", "-1 steps", "1.5 steps", "5 files"} {
+		_, err := formatting.ParseSteps(input)
+		assert.ErrorIs(t, err, formatting.ErrSyntax, input)
+	}
+
+	_, err = formatting.ParseSteps("18446744073709551616 steps")
+	assert.ErrorIs(t, err, formatting.ErrRange)
+}
@@ -14,3 +14,13 @@ type Unit interface {
 	// optimized way (e.g., "1 / 3 steps" instead of "1 step / 3 steps").
 	RenderProgress(current uint64, total uint64) string
 }
+
+// Parser is implemented by units that can parse the values they render (e.g.,
+// to accept command-line flags in the same format that progress is displayed
+// in).
+type Parser interface {
+	// Parse the value from a string rendered by the unit.
+	//
+	// For example, if the unit is bytes, this might parse "1 KiB" as 1,024.
+	Parse(s string) (uint64, error)
+}
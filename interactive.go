@@ -0,0 +1,440 @@
+package mon
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	selectedMarker   = "› "
+	unselectedMarker = "  "
+
+	expandedIcon  = "▾"
+	collapsedIcon = "▸"
+
+	// categoryIndent is the indentation of tasks beneath their category.
+	categoryIndent = "  "
+
+	// detailLogLines is the number of lines from the end of the task's log
+	// that are shown in the detail pane.
+	detailLogLines = 10
+)
+
+// helpText lists the keys available in the interactive view.
+var helpText = []string{
+	"↑/k ↓/j select · pgup/pgdown scroll · home/end first/last · esc deselect",
+	"←/h →/l collapse/expand · space toggle category · enter/d details",
+	"x cancel selected task · c show/hide completed tasks · ? help",
+}
+
+// row is a single row of the task list. It is either a category header (if
+// task is nil) or a task.
+type row struct {
+	category string
+	task     Task
+}
+
+// is returns true if the rows refer to the same category header or task.
+func (r row) is(o row) bool {
+	if r.task != nil || o.task != nil {
+		return r.task == o.task
+	}
+
+	return r.category == o.category
+}
+
+// isTerminal returns true if the given file is a terminal (a character
+// device).
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// visibleTasks returns the tasks that should be displayed by the monitor.
+func (m *model) visibleTasks() []Task {
+	m.tasksMutex.RLock()
+	defer m.tasksMutex.RUnlock()
+
+	showCompleted := m.showCompleted.Load()
+
+	tasks := make([]Task, 0, len(m.tasks))
+	for _, t := range m.tasks {
+		if !showCompleted && isExpired(t) {
+			continue
+		}
+
+		tasks = append(tasks, t)
+	}
+
+	return tasks
+}
+
+// rows arranges the given tasks into rows.
+//
+// In interactive mode, tasks without a category are listed first, followed by
+// each category (in the order it first appears) with its tasks beneath it,
+// unless it has been collapsed. Otherwise, the tasks are listed in the order
+// they were added.
+func (m *model) rows(tasks []Task) []row {
+	rows := make([]row, 0, len(tasks))
+
+	if !m.interactive {
+		for _, t := range tasks {
+			rows = append(rows, row{task: t})
+		}
+
+		return rows
+	}
+
+	var categories []string
+	for _, t := range tasks {
+		if category := t.GetCategory(); category == "" {
+			rows = append(rows, row{task: t})
+		} else if !slices.Contains(categories, category) {
+			categories = append(categories, category)
+		}
+	}
+
+	for _, category := range categories {
+		rows = append(rows, row{category: category})
+		if m.collapsed[category] {
+			continue
+		}
+
+		for _, t := range tasks {
+			if t.GetCategory() == category {
+				rows = append(rows, row{category: category, task: t})
+			}
+		}
+	}
+
+	return rows
+}
+
+// selectedIndex returns the index of the selected row, or -1 if there is no
+// selection or the selected row is no longer displayed.
+func (m *model) selectedIndex(rows []row) int {
+	if !m.hasSelection {
+		return -1
+	}
+
+	return slices.IndexFunc(rows, m.selection.is)
+}
+
+// selectedTask returns the selected task, or nil if a task is not selected.
+func (m *model) selectedTask(rows []row) Task {
+	if i := m.selectedIndex(rows); i >= 0 {
+		return rows[i].task
+	}
+
+	return nil
+}
+
+// handleKey updates the interactive view for the given key press. It returns
+// false if the key is not an interactive control.
+func (m *model) handleKey(msg tea.KeyMsg) bool {
+	if !m.interactive {
+		return false
+	}
+
+	rows := m.rows(m.visibleTasks())
+	page := max(m.height/2, 1)
+
+	switch msg.String() {
+	case "up", "k":
+		m.moveSelection(rows, -1)
+	case "down", "j":
+		m.moveSelection(rows, 1)
+	case "pgup":
+		m.moveSelection(rows, -page)
+	case "pgdown":
+		m.moveSelection(rows, page)
+	case "home":
+		m.moveSelection(rows, -len(rows))
+	case "end":
+		m.moveSelection(rows, len(rows))
+	case "esc":
+		m.hasSelection = false
+		m.showDetail = false
+	case "left", "h":
+		m.setCollapsed(rows, true)
+	case "right", "l":
+		m.setCollapsed(rows, false)
+	case " ", "space":
+		if i := m.selectedIndex(rows); i >= 0 {
+			m.setCollapsed(rows, !m.collapsed[rows[i].category])
+		}
+	case "enter", "d":
+		m.showDetail = !m.showDetail
+	case "x":
+		if selected := m.selectedTask(rows); selected != nil {
+			selected.Cancel(ErrTaskCancelled)
+		}
+	case "c":
+		m.showCompleted.Store(!m.showCompleted.Load())
+	case "?":
+		m.showHelp = !m.showHelp
+	default:
+		return false
+	}
+
+	return true
+}
+
+// moveSelection moves the selection by delta rows, clamped to the list. If
+// nothing is selected, the first (or last, if delta is negative) row is
+// selected instead.
+func (m *model) moveSelection(rows []row, delta int) {
+	if len(rows) == 0 {
+		return
+	}
+
+	i := m.selectedIndex(rows)
+	switch {
+	case i < 0 && delta < 0:
+		i = len(rows) - 1
+	case i < 0:
+		i = 0
+	default:
+		i = min(max(i+delta, 0), len(rows)-1)
+	}
+
+	m.selection = rows[i]
+	m.hasSelection = true
+}
+
+// setCollapsed collapses or expands the category of the selected row. When a
+// category is collapsed, its header is selected.
+func (m *model) setCollapsed(rows []row, collapsed bool) {
+	i := m.selectedIndex(rows)
+	if i < 0 || rows[i].category == "" {
+		return
+	}
+
+	category := rows[i].category
+	if m.collapsed == nil {
+		m.collapsed = make(map[string]bool)
+	}
+	m.collapsed[category] = collapsed
+
+	if collapsed {
+		m.selection = row{category: category}
+	}
+}
+
+// renderRows renders the task list, scrolled to fit within the given number of
+// lines (if positive) whilst keeping the selected row visible.
+func (m *model) renderRows(rows []row, tasks []Task, spinner string, lines int) string {
+	widths := m.columnWidths(tasks)
+
+	rendered := make([]string, len(rows))
+	for i, r := range rows {
+		var s string
+		if r.task == nil {
+			s = renderCategory(r.category, tasks, m.collapsed[r.category])
+		} else {
+			s = m.renderTask(r.task, widths, spinner)
+			if r.category != "" {
+				s = indentLines(s, categoryIndent, categoryIndent)
+			}
+		}
+
+		if m.hasSelection {
+			marker := unselectedMarker
+			if m.selection.is(r) {
+				marker = selectedMarker
+			}
+			s = indentLines(s, marker, unselectedMarker)
+		}
+
+		rendered[i] = s
+	}
+
+	if lines <= 0 || countLines(rendered...) <= lines {
+		m.offset = 0
+		return strings.Join(rendered, "")
+	}
+
+	// Reserve a line either side of the list for the scroll indicators.
+	lines = max(lines-2, 1)
+
+	if selected := m.selectedIndex(rows); selected >= 0 {
+		m.offset = min(m.offset, selected)
+		for m.offset < selected && countLines(rendered[m.offset:selected+1]...) > lines {
+			m.offset++
+		}
+	}
+	m.offset = min(max(m.offset, 0), len(rendered)-1)
+
+	end := m.offset + 1
+	for end < len(rendered) && countLines(rendered[m.offset:end+1]...) <= lines {
+		end++
+	}
+
+	var s strings.Builder
+	if m.offset > 0 {
+		s.WriteString(dimStyle.Render(fmt.Sprintf("↑ %d more", m.offset)))
+	}
+	s.WriteRune('\n')
+
+	for _, r := range rendered[m.offset:end] {
+		s.WriteString(r)
+	}
+
+	if remaining := len(rendered) - end; remaining > 0 {
+		s.WriteString(dimStyle.Render(fmt.Sprintf("↓ %d more", remaining)))
+	}
+	s.WriteRune('\n')
+
+	return s.String()
+}
+
+// renderCategory renders the header row of a category, summarizing the tasks
+// within it.
+func renderCategory(category string, tasks []Task, collapsed bool) string {
+	var total, completed, failed, cancelled, skipped int
+	for _, t := range tasks {
+		if t.GetCategory() != category {
+			continue
+		}
+
+		total++
+		switch t.GetState() {
+		case TaskStateCompleted:
+			completed++
+		case TaskStateFailed:
+			failed++
+		case TaskStateCancelled:
+			cancelled++
+		case TaskStateSkipped:
+			skipped++
+		}
+	}
+
+	icon := expandedIcon
+	if collapsed {
+		icon = collapsedIcon
+	}
+
+	summary := fmt.Sprintf(" (%d/%d complete", completed, total)
+	if failed > 0 {
+		summary += fmt.Sprintf(", %d failed", failed)
+	}
+	if cancelled > 0 {
+		summary += fmt.Sprintf(", %d cancelled", cancelled)
+	}
+	if skipped > 0 {
+		summary += fmt.Sprintf(", %d skipped", skipped)
+	}
+	summary += ")"
+
+	return boldStyle.Render(icon+" "+category) + dimStyle.Render(summary) + "\n"
+}
+
+// renderDetail renders the detail pane for the given task.
+func (m *model) renderDetail(t Task) string {
+	var s strings.Builder
+
+	field := func(label string, value string) {
+		if value == "" {
+			return
+		}
+
+		s.WriteString(dimStyle.Render(fmt.Sprintf("%-10s ", label+":")))
+		s.WriteString(indentLines(value+"\n", "", strings.Repeat(" ", 11)))
+	}
+
+	s.WriteRune('\n')
+	field("ID", t.GetID())
+	field("Name", t.GetName())
+	field("Category", t.GetCategory())
+	field("Caption", t.GetCaption())
+	field("State", t.GetState().String())
+	field("Started", t.GetStartedAt().Format(time.TimeOnly))
+	if t.IsCompleted() {
+		field("Completed", t.GetCompletedAt().Format(time.TimeOnly))
+	}
+	field("Elapsed", m.formatDuration(t.GetElapsed()))
+
+	if !t.IsIndeterminate() {
+		field("Progress", fmt.Sprintf("%s (%0.1f%%)", renderProgress(t), t.GetProgress()*100))
+	}
+
+	if eta, ok := t.GetEstimatedCompletion(); ok {
+		field("ETA", m.formatDuration(eta))
+	}
+
+	if t.IsError() {
+		field("Error", errorStyle.Render(t.GetError().Error()))
+	}
+
+	if t.IsCancelled() {
+		field("Cause", warningStyle.Render(t.GetError().Error()))
+	}
+
+	if t.GetState() == TaskStateSkipped {
+		field("Skipped", t.GetError().Error())
+	}
+
+	if t.IsBlocked() {
+		field("Waiting", strings.Join(getBlockingNames(t), ", "))
+	}
+
+	if log := t.GetLog(); len(log) > 0 {
+		if len(log) > detailLogLines {
+			log = log[len(log)-detailLogLines:]
+		}
+		field("Log", strings.Join(log, "\n"))
+	}
+
+	return s.String()
+}
+
+// renderHelp renders the help footer.
+func renderHelp() string {
+	var s strings.Builder
+	for _, line := range helpText {
+		s.WriteString(dimStyle.Render(line))
+		s.WriteRune('\n')
+	}
+
+	return s.String()
+}
+
+// indentLines prefixes the first line of s with first and each subsequent line
+// with rest.
+func indentLines(s string, first string, rest string) string {
+	lines := strings.SplitAfter(s, "\n")
+
+	var b strings.Builder
+	for i, line := range lines {
+		if line == "" {
+			continue
+		}
+
+		if i == 0 {
+			b.WriteString(first)
+		} else {
+			b.WriteString(rest)
+		}
+		b.WriteString(line)
+	}
+
+	return b.String()
+}
+
+// countLines returns the total number of lines in the given newline-terminated
+// strings.
+func countLines(s ...string) int {
+	n := 0
+	for _, str := range s {
+		n += strings.Count(str, "\n")
+	}
+
+	return n
+}
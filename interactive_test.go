@@ -0,0 +1,219 @@
+package mon_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/apollosoftwarexyz/mon"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	keyUp    = tea.KeyMsg{Type: tea.KeyUp}
+	keyDown  = tea.KeyMsg{Type: tea.KeyDown}
+	keyLeft  = tea.KeyMsg{Type: tea.KeyLeft}
+	keyRight = tea.KeyMsg{Type: tea.KeyRight}
+	keyEnter = tea.KeyMsg{Type: tea.KeyEnter}
+	keyEsc   = tea.KeyMsg{Type: tea.KeyEsc}
+)
+
+// keyRune returns the key press for the given character.
+func keyRune(r rune) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}
+}
+
+// viewLines renders the monitor and returns the lines of the view, with
+// trailing whitespace removed.
+func viewLines(m mon.M) []string {
+	lines := strings.Split(strings.TrimSuffix(mon.View(m), "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+
+	return lines
+}
+
+func TestInteractive_disabled(t *testing.T) {
+	m := mon.New("test")
+	m.AddTask().Name("task").Apply()
+
+	// Without a terminal, keys do not change the view.
+	before := viewLines(m)
+	mon.Press(m, keyDown, keyEnter, keyRune('?'))
+	assert.Equal(t, len(before), len(viewLines(m)))
+	assert.NotContains(t, mon.View(m), "›")
+}
+
+func TestInteractive_selection(t *testing.T) {
+	m := mon.New("test")
+	mon.Interactive(m, 0)
+	m.AddTask().Name("first").Apply()
+	m.AddTask().Name("second").Apply()
+
+	assert.NotContains(t, mon.View(m), "›")
+
+	mon.Press(m, keyDown)
+	assert.Contains(t, viewLines(m)[0], "› ")
+	assert.Contains(t, viewLines(m)[0], "first")
+
+	mon.Press(m, keyDown, keyDown)
+	assert.Contains(t, viewLines(m)[1], "› ")
+	assert.Contains(t, viewLines(m)[1], "second")
+
+	mon.Press(m, keyUp)
+	assert.Contains(t, viewLines(m)[0], "› ")
+
+	mon.Press(m, keyEsc)
+	assert.NotContains(t, mon.View(m), "›")
+}
+
+func TestInteractive_categories(t *testing.T) {
+	m := mon.New("test")
+	mon.Interactive(m, 0)
+	m.AddTask().Name("build").Category("ci").Apply()
+	m.AddTask().Name("ungrouped").Apply()
+	m.AddTask().Name("test").Category("ci").Apply().CompleteStep()
+
+	lines := viewLines(m)
+	assert.Contains(t, lines[0], "ungrouped")
+	assert.Contains(t, lines[1], "▾ ci (1/2 complete)")
+	assert.Contains(t, lines[2], "build")
+	assert.Contains(t, lines[3], "test")
+
+	// Collapse the category from one of its tasks.
+	mon.Press(m, keyDown, keyDown, keyDown, keyLeft)
+	lines = viewLines(m)
+	assert.Contains(t, lines[1], "› ▸ ci")
+	assert.NotContains(t, mon.View(m), "build")
+
+	mon.Press(m, keyRight)
+	assert.Contains(t, mon.View(m), "build")
+
+	mon.Press(m, keyRune(' '))
+	assert.NotContains(t, mon.View(m), "build")
+}
+
+func TestInteractive_detail(t *testing.T) {
+	m := mon.New("test")
+	mon.Interactive(m, 0)
+	task := m.AddTask().ID("the-id").Name("task").Apply()
+	_, _ = task.Writer().Write([]byte("log line\n"))
+	task.Error(mockError)
+
+	mon.Press(m, keyDown, keyEnter)
+	view := mon.View(m)
+	assert.Contains(t, view, "the-id")
+	assert.Contains(t, view, "failed")
+	assert.Contains(t, view, "mock error")
+	assert.Contains(t, view, "log line")
+
+	mon.Press(m, keyRune('d'))
+	assert.NotContains(t, mon.View(m), "the-id")
+}
+
+func TestInteractive_cancel(t *testing.T) {
+	m := mon.New("test")
+	mon.Interactive(m, 0)
+	first := m.AddTask().Name("first").Apply()
+	second := m.AddTask().Name("second").Apply()
+
+	mon.Press(m, keyDown, keyDown, keyRune('x'))
+	assert.False(t, first.IsCancelled())
+	assert.True(t, second.IsCancelled())
+	assert.Equal(t, mon.ErrTaskCancelled, context.Cause(second.Context()))
+	assert.Contains(t, mon.View(m), "cancelled: "+mon.ErrTaskCancelled.Error())
+}
+
+func TestInteractive_help(t *testing.T) {
+	m := mon.New("test")
+	mon.Interactive(m, 0)
+
+	mon.Press(m, keyRune('?'))
+	assert.Contains(t, mon.View(m), "show/hide completed tasks")
+
+	mon.Press(m, keyRune('?'))
+	assert.NotContains(t, mon.View(m), "show/hide completed tasks")
+}
+
+func TestInteractive_scroll(t *testing.T) {
+	m := mon.New("test")
+	mon.Interactive(m, 10)
+	for _, name := range []string{"t0", "t1", "t2", "t3", "t4", "t5", "t6", "t7", "t8", "t9"} {
+		m.AddTask().Name(name).Apply()
+	}
+
+	// The list is limited to the height of the terminal.
+	lines := viewLines(m)
+	assert.LessOrEqual(t, len(lines), 10)
+	assert.Equal(t, "", lines[0])
+	assert.Contains(t, lines[1], "t0")
+	assert.Contains(t, lines[5], "t4")
+	assert.Equal(t, "↓ 5 more", lines[6])
+
+	// Moving the selection beyond the end scrolls the list.
+	mon.Press(m, keyDown, keyDown, keyDown, keyDown, keyDown, keyDown)
+	lines = viewLines(m)
+	assert.Equal(t, "↑ 1 more", lines[0])
+	assert.Contains(t, lines[1], "t1")
+	assert.Contains(t, lines[5], "› ")
+	assert.Contains(t, lines[5], "t5")
+	assert.Equal(t, "↓ 4 more", lines[6])
+
+	// Jumping to the end shows the last tasks.
+	mon.Press(m, tea.KeyMsg{Type: tea.KeyEnd})
+	lines = viewLines(m)
+	assert.Equal(t, "↑ 5 more", lines[0])
+	assert.Contains(t, lines[5], "t9")
+	assert.Equal(t, "", lines[6])
+}
+
+func TestInteractive_showCompleted(t *testing.T) {
+	m := mon.New("test")
+	mon.Interactive(m, 0)
+	m.AddTask().Name("running").Apply()
+	finished := m.AddTask().Name("finished").Apply()
+	finished.CompleteStep()
+	mon.Expire(finished)
+
+	mon.Tick(m)
+	assert.NotContains(t, mon.View(m), "finished")
+
+	// Tasks that expired before being shown can still be revealed.
+	mon.Press(m, keyRune('c'))
+	assert.Contains(t, mon.View(m), "finished")
+
+	mon.Press(m, keyRune('c'))
+	assert.NotContains(t, mon.View(m), "finished")
+}
+
+func TestInteractive_showCompleted_limit(t *testing.T) {
+	m := mon.New("test")
+	mon.Interactive(m, 0)
+
+	tasks := make([]mon.Task, mon.MaxExpiredTasks+1)
+	for i := range tasks {
+		tasks[i] = m.AddTask().Apply()
+		tasks[i].CompleteStep()
+		mon.Expire(tasks[i])
+	}
+
+	// Only the most recent expired tasks are kept.
+	mon.Tick(m)
+	assert.Equal(t, tasks[1:], m.Tasks())
+	assert.Equal(t, mon.MaxExpiredTasks+1, m.Stats().Total)
+}
+
+func TestInteractive_disabled_categories(t *testing.T) {
+	m := mon.New("test")
+	m.AddTask().Name("build").Category("ci").Apply()
+	m.AddTask().Name("ungrouped").Apply()
+
+	// Without a terminal, tasks are listed in the order they were added,
+	// without category headers.
+	lines := viewLines(m)
+	assert.Contains(t, lines[0], "build")
+	assert.Contains(t, lines[1], "ungrouped")
+	assert.NotContains(t, mon.View(m), "▾")
+}
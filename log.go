@@ -0,0 +1,119 @@
+package mon
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+type logFn func(line string)
+
+// logLine prints the given line above the live region of the monitor.
+//
+// If the monitor is not currently being shown, the line is written directly to
+// the monitor's output instead.
+func (m *model) logLine(line string) {
+	if m.prog == nil || !m.running.Load() {
+		_, _ = fmt.Fprintln(m.out, line)
+		return
+	}
+
+	m.prog.Println(line)
+}
+
+// taskPrefix returns the prefix used for log lines that belong to the given
+// task, or an empty string if the task has no name.
+func taskPrefix(t Task) string {
+	if name := t.GetName(); name != "" {
+		return name + ": "
+	}
+
+	return ""
+}
+
+// logWriter is an [io.Writer] that buffers writes until a complete line is
+// available and then prints the line with a [logFn].
+//
+// As in a terminal, a carriage return within a line discards the text before
+// it, so only the final state of progress output (e.g., "45%\r46%\r47%") is
+// printed.
+type logWriter struct {
+	log    logFn
+	prefix func() string
+
+	// carriageReturns, if true, treats carriage returns as line terminators
+	// (instead of discarding the text before them) so that each update to a
+	// progress line is printed.
+	carriageReturns bool
+
+	mutex sync.Mutex
+	buf   []byte
+}
+
+func (w *logWriter) Write(p []byte) (int, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	terminators := "\n"
+	if w.carriageReturns {
+		terminators = "\r\n"
+	}
+
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexAny(w.buf, terminators)
+		if i < 0 {
+			break
+		}
+
+		line := string(w.buf[:i])
+		w.buf = w.buf[i+1:]
+		w.emit(line)
+	}
+
+	return len(p), nil
+}
+
+// Flush prints any incomplete line that remains buffered, such as output
+// that did not end with a newline.
+func (w *logWriter) Flush() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if len(w.buf) == 0 {
+		return
+	}
+
+	line := string(w.buf)
+	w.buf = nil
+	w.emit(line)
+}
+
+// emit prints a single line (without its terminator). The caller must hold
+// the mutex.
+func (w *logWriter) emit(line string) {
+	line = strings.TrimSuffix(line, "\r")
+	if j := strings.LastIndexByte(line, '\r'); j >= 0 {
+		line = line[j+1:]
+	}
+
+	if w.carriageReturns && line == "" {
+		return
+	}
+
+	if w.prefix != nil {
+		line = w.prefix() + line
+	}
+	w.log(line)
+}
+
+// newTaskWriter creates an [io.Writer] that prints lines prefixed with the
+// name of the given task.
+func newTaskWriter(log logFn, t Task) io.Writer {
+	return &logWriter{
+		log:    log,
+		prefix: func() string { return taskPrefix(t) },
+	}
+}
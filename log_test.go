@@ -0,0 +1,66 @@
+package mon_test
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/apollosoftwarexyz/mon"
+	"github.com/stretchr/testify/assert"
+)
+
+// createLoggingMonitor creates a new monitor that writes log lines to the
+// returned builder.
+func createLoggingMonitor() (mon.M, *strings.Builder) {
+	var out strings.Builder
+	m := mon.New("test")
+	mon.SetOutput(m, &out)
+	return m, &out
+}
+
+func TestM_Log(t *testing.T) {
+	m, out := createLoggingMonitor()
+
+	m.Log("hello ", "world")
+	m.Logf("%d %s\n", 2, "lines")
+	assert.Equal(t, "hello world\n2 lines\n", out.String())
+}
+
+func TestM_Writer(t *testing.T) {
+	m, out := createLoggingMonitor()
+	w := m.Writer()
+
+	// Incomplete lines are buffered until a newline is written.
+	_, _ = fmt.Fprint(w, "partial")
+	assert.Empty(t, out.String())
+
+	_, _ = fmt.Fprint(w, " line\r\nsecond line\nthird")
+	assert.Equal(t, "partial line\nsecond line\n", out.String())
+
+	logger := log.New(w, "", 0)
+	logger.Print("from logger")
+	assert.Equal(t, "partial line\nsecond line\nthirdfrom logger\n", out.String())
+}
+
+func TestM_TaskWriter(t *testing.T) {
+	m, out := createLoggingMonitor()
+	task := m.AddTask().Name("build").Apply()
+	unnamed := m.AddTask().Apply()
+
+	_, _ = fmt.Fprintln(m.TaskWriter(task), "compiling")
+	_, _ = fmt.Fprintln(m.TaskWriter(unnamed), "no prefix")
+	assert.Equal(t, "build: compiling\nno prefix\n", out.String())
+}
+
+func TestTask_Log(t *testing.T) {
+	m, out := createLoggingMonitor()
+	task := m.AddTask().Name("build").Apply()
+
+	task.Log("started")
+	task.Logf("%d%% done", 50)
+
+	task.SetName("rebuild")
+	task.Log("renamed")
+	assert.Equal(t, "build: started\nbuild: 50% done\nrebuild: renamed\n", out.String())
+}
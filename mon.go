@@ -2,9 +2,17 @@ package mon
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/apollosoftwarexyz/mon/animations"
+	"github.com/apollosoftwarexyz/mon/formatting"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
@@ -14,9 +22,38 @@ type M interface {
 	// task to the monitor.
 	AddTask() TaskBuilder
 
+	// RemoveTask removes the given task from the monitor.
+	//
+	// The task itself is unaffected and may continue to be updated, but it will
+	// no longer be displayed. If the task is not tracked by the monitor, this
+	// function is a no-op.
+	RemoveTask(task Task)
+
+	// Tasks returns the tasks currently tracked by the monitor, in the order
+	// they were added.
+	//
+	// Completed tasks are automatically removed from the monitor once they
+	// have been displayed for their retention window, so they will not be
+	// returned after that point. When the monitor is shown interactively, a
+	// limited number of completed tasks are instead retained (but hidden) so
+	// that the user can choose to show them again.
+	Tasks() []Task
+
+	// Clear removes all tasks from the monitor (and resets [M.Stats]).
+	Clear()
+
+	// Task returns the task with the given ID (see [Task.GetID]) if it is
+	// tracked by the monitor. Otherwise, nil and false are returned.
+	Task(id string) (Task, bool)
+
+	// FindTasks returns the tasks tracked by the monitor that match the given
+	// filter, in the order they were added.
+	FindTasks(filter TaskFilter) []Task
+
 	// IsCancellationBlocked returns true if cancellation has been blocked with
 	// [M.BlockCancellation] (or if it has been unblocked with
-	// [M.AllowCancellation]).
+	// [M.AllowCancellation]), or if any scope created with
+	// [M.BlockCancellationScope] has not yet been released.
 	IsCancellationBlocked() bool
 
 	// AllowCancellation permits the user to press Ctrl+C to cancel the context
@@ -38,18 +75,172 @@ type M interface {
 	// The same monitor instance is returned to allow for a fluent API.
 	BlockCancellation() M
 
+	// BlockCancellationScope blocks cancellation until the returned release
+	// function is called, displaying the given reason to the user.
+	//
+	// Unlike [M.BlockCancellation], scopes may be nested or held by several
+	// goroutines at once: cancellation remains blocked until every scope has
+	// been released. If the user presses Ctrl+C whilst a scope is active, the
+	// cancellation is deferred and applied once the last scope is released.
+	//
+	// The release function may safely be called more than once, so it can be
+	// deferred immediately:
+	//
+	//	release := m.BlockCancellationScope("saving state")
+	//	defer release()
+	BlockCancellationScope(reason string) (release func())
+
+	// ConfirmCancellation requires the user to confirm cancellation by
+	// pressing Ctrl+C twice.
+	//
+	// The first press displays a banner asking the user to press Ctrl+C again.
+	// The second press cancels the context used by the monitor (but leaves the
+	// monitor displayed so that tasks can finish cleaning up). A third press
+	// closes the monitor, restoring the terminal, and (if [M.ExitOnForceQuit]
+	// is set) exits the process.
+	//
+	// If cancellation is blocked (see [M.BlockCancellation]), pressing Ctrl+C
+	// displays a hint that cancellation is not currently possible instead.
+	//
+	// The same monitor instance is returned to allow for a fluent API.
+	ConfirmCancellation() M
+
+	// ExitOnForceQuit exits the process with status 130 (the conventional
+	// status of a process interrupted by SIGINT) when the user forces the
+	// monitor to quit by pressing Ctrl+C a third time (see
+	// [M.ConfirmCancellation]), once the terminal has been restored.
+	//
+	// This is useful when tasks may not respond to cancellation. By default,
+	// the monitor is closed but the process continues to run.
+	//
+	// The same monitor instance is returned to allow for a fluent API.
+	ExitOnForceQuit() M
+
+	// Stats returns statistics aggregated across the monitor's tasks, such as
+	// the number of completed tasks and their overall progress.
+	Stats() Stats
+
+	// ShowOverallProgress displays the overall progress of the monitor's tasks
+	// (see [M.Stats]) in the footer of the monitor: the number of completed
+	// tasks, the combined progress and estimated completion time of the
+	// determinate tasks and the number of failed tasks.
+	//
+	// The same monitor instance is returned to allow for a fluent API.
+	ShowOverallProgress() M
+
+	// ShowWindowTitle sets the title of the terminal window to the caption and
+	// overall progress of the monitor (see [M.ShowOverallProgress]) while it
+	// is shown, so progress can be followed from a background tab. The title
+	// is cleared when the monitor is closed.
+	//
+	// The same monitor instance is returned to allow for a fluent API.
+	ShowWindowTitle() M
+
+	// ShowTaskbarProgress reports the overall progress of the monitor's tasks
+	// to the terminal with the OSC 9;4 sequence (supported by Windows Terminal,
+	// ConEmu, Ghostty and others) while it is shown. The progress is cleared
+	// when the monitor is closed.
+	//
+	// The same monitor instance is returned to allow for a fluent API.
+	ShowTaskbarProgress() M
+
+	// DurationFormat sets the format of the durations displayed by the
+	// monitor, such as the elapsed time and estimated completion time of its
+	// tasks. By default, durations are displayed with
+	// [formatting.DurationClock].
+	//
+	// The same monitor instance is returned to allow for a fluent API.
+	DurationFormat(format formatting.DurationFormat) M
+
+	// DurationPrecision sets the precision of the fractional seconds in the
+	// durations displayed by the monitor (see
+	// [formatting.DurationFormat.FormatPrecision]), such as [time.Millisecond]
+	// for fast tasks. By default, durations are displayed with
+	// [formatting.DefaultDurationPrecision].
+	//
+	// The same monitor instance is returned to allow for a fluent API.
+	DurationPrecision(precision time.Duration) M
+
+	// Log prints a message above the live region of the monitor. Arguments are
+	// handled in the manner of [fmt.Print].
+	//
+	// Unlike writing to the terminal directly, this does not corrupt the
+	// monitor's display while it is being shown. If the monitor is not being
+	// shown, the message is written to standard output.
+	Log(a ...any)
+
+	// Logf prints a message above the live region of the monitor. Arguments
+	// are handled in the manner of [fmt.Printf].
+	//
+	// See [M.Log] for details.
+	Logf(format string, a ...any)
+
+	// Writer returns an [io.Writer] that prints each line written to it above
+	// the live region of the monitor (see [M.Log]). Incomplete lines are
+	// buffered until a newline is written.
+	//
+	// This is suitable for use with [log.SetOutput] or as the target of a
+	// [log/slog.Handler].
+	Writer() io.Writer
+
+	// TaskWriter returns an [io.Writer] like [M.Writer], except that each line
+	// is prefixed with the name of the given task (if it has one).
+	TaskWriter(task Task) io.Writer
+
+	// Exec runs the given command as a task, created by applying the given
+	// builder, and waits for it to exit. If the task has dependencies (see
+	// [TaskBuilder.DependsOn]), the command is started once they complete.
+	//
+	// The command's standard output and error are written to the task's log
+	// (see [Task.Writer]) in addition to any writers already set on the
+	// command. If the context (or the task, see [Task.Cancel]) is cancelled,
+	// the command is killed. Once the command has exited, its output is only
+	// awaited for the command's WaitDelay (or half a second, if it is not
+	// set), so that processes it started in the background cannot keep Exec
+	// waiting.
+	//
+	// Each line of output is passed to the given parsers (such as
+	// [ParsePercent]) in order, and the progress reported by the first parser
+	// that recognizes the line is applied to the task. The task is completed
+	// once the command exits successfully.
+	//
+	// If the command fails to start or exits unsuccessfully, the task is failed
+	// with the error, which is also returned. A non-zero exit code is reported
+	// as an [*ExitError].
+	Exec(ctx context.Context, builder TaskBuilder, cmd *exec.Cmd, parsers ...ProgressParser) (Task, error)
+
 	// GetCaption of the monitor.
 	GetCaption() string
 
 	// SetCaption of the monitor.
 	SetCaption(caption string)
 
+	// SetHeader replaces the sections displayed above the monitor's tasks.
+	// Each section is computed on every frame, so it may display dynamic
+	// information (such as memory use or queue depth).
+	//
+	// Calling SetHeader with no sections removes the header.
+	SetHeader(sections ...Section)
+
+	// SetFooter replaces the sections displayed beneath the monitor's caption.
+	// Each section is computed on every frame, so it may display dynamic
+	// information (such as a rotating tip).
+	//
+	// Calling SetFooter with no sections removes the footer.
+	SetFooter(sections ...Section)
+
 	// Show the monitor in the CLI.
 	//
 	// The [CancelFunc] should be deferred immediately after Show is called:
 	//
 	//	ctx, cancel := m.Show(context.WithCancelCause(context.Background()))
 	//	defer cancel()
+	//
+	// When the monitor cancels the context, the cause (see [context.Cause])
+	// describes why: [ErrUserInterrupted] if the user pressed Ctrl+C, one of
+	// [ErrInterruptSignal], [ErrTerminateSignal] or [ErrHangupSignal] if the
+	// process received the corresponding signal, or the cause given to the
+	// returned cancel function ([ErrMonitorClosed] if it is nil).
 	Show(ctx context.Context, cancel context.CancelCauseFunc) (context.Context, context.CancelCauseFunc)
 }
 
@@ -60,6 +251,7 @@ func New(caption string) M {
 	return &model{
 		spinnerAnim:  animations.Default(),
 		ellipsisAnim: animations.Ellipsis(),
+		out:          os.Stdout,
 		start:        time.Now(),
 		caption:      caption,
 		exited:       make(chan error),
@@ -70,8 +262,54 @@ func (m *model) AddTask() TaskBuilder {
 	return &taskBuilder{m: m}
 }
 
+func (m *model) RemoveTask(task Task) {
+	m.removeTask(task)
+	m.notify()
+}
+
+func (m *model) Tasks() []Task {
+	m.tasksMutex.RLock()
+	defer m.tasksMutex.RUnlock()
+	return slices.Clone(m.tasks)
+}
+
+func (m *model) Clear() {
+	m.tasksMutex.Lock()
+	m.tasks = nil
+	m.retiredStats = Stats{}
+	m.tasksMutex.Unlock()
+	m.notify()
+}
+
+func (m *model) Task(id string) (Task, bool) {
+	m.tasksMutex.RLock()
+	defer m.tasksMutex.RUnlock()
+
+	for _, t := range m.tasks {
+		if t.GetID() == id {
+			return t, true
+		}
+	}
+
+	return nil, false
+}
+
+func (m *model) FindTasks(filter TaskFilter) []Task {
+	m.tasksMutex.RLock()
+	defer m.tasksMutex.RUnlock()
+
+	tasks := make([]Task, 0)
+	for _, t := range m.tasks {
+		if filter.Match(t) {
+			tasks = append(tasks, t)
+		}
+	}
+
+	return tasks
+}
+
 func (m *model) IsCancellationBlocked() bool {
-	return m.blockCancellation
+	return m.blockCancellation || len(m.blockReasons()) > 0
 }
 
 func (m *model) AllowCancellation() M {
@@ -84,6 +322,48 @@ func (m *model) BlockCancellation() M {
 	return m
 }
 
+func (m *model) Log(a ...any) {
+	m.logLine(strings.TrimSuffix(fmt.Sprint(a...), "\n"))
+}
+
+func (m *model) Logf(format string, a ...any) {
+	m.logLine(strings.TrimSuffix(fmt.Sprintf(format, a...), "\n"))
+}
+
+func (m *model) Writer() io.Writer {
+	return &logWriter{log: m.logLine}
+}
+
+func (m *model) TaskWriter(task Task) io.Writer {
+	return newTaskWriter(m.logLine, task)
+}
+
+func (m *model) ConfirmCancellation() M {
+	m.confirmCancellation = true
+	return m
+}
+
+func (m *model) ExitOnForceQuit() M {
+	m.exitOnForceQuit = true
+	return m
+}
+
+func (m *model) DurationFormat(format formatting.DurationFormat) M {
+	m.durationFormat = format
+	return m
+}
+
+func (m *model) DurationPrecision(precision time.Duration) M {
+	m.durationPrecision = precision
+	return m
+}
+
+// formatDuration formats the given duration with the monitor's duration format
+// and precision.
+func (m *model) formatDuration(d time.Duration) string {
+	return m.durationFormat.FormatPrecision(d, m.durationPrecision)
+}
+
 func (m *model) GetCaption() string {
 	return m.caption
 }
@@ -94,21 +374,47 @@ func (m *model) SetCaption(caption string) {
 }
 
 func (m *model) Show(ctx context.Context, cancel context.CancelCauseFunc) (context.Context, context.CancelCauseFunc) {
-	m.prog = tea.NewProgram(m, tea.WithContext(ctx))
+	// The program is deliberately not given ctx, as bubbletea kills the
+	// program when its context is cancelled. This way, the monitor remains
+	// displayed when ctx is cancelled (e.g., once the user has confirmed
+	// cancellation) until it is closed with the returned cancel function.
+	m.prog = tea.NewProgram(m, append([]tea.ProgramOption{tea.WithoutSignalHandler()}, m.programOptions...)...)
+	m.interactive = isTerminal(os.Stdin)
+	m.cancel = cancel
 
+	m.running.Store(true)
+	stopSignals := m.handleSignals()
 	go func() {
 		_, err := m.prog.Run()
+		stopSignals()
+		m.running.Store(false)
+		m.exitIfForced()
+
+		// The bubbletea program has exited, so it is safe to read the cause
+		// set by the model.
+		if m.cause != nil {
+			err = m.cause
+		}
 		cancel(err)
 		m.exited <- err
 		close(m.exited)
 	}()
 
+	var summarize sync.Once
 	return ctx, func(cause error) {
-		m.notifyDone()
+		if cause == nil {
+			cause = ErrMonitorClosed
+		}
+		m.notifyDone(cause)
 
 		// Wait for the bubbletea application to quit.
 		select {
 		case <-m.exited:
 		}
+
+		summarize.Do(func() {
+			m.resetTerminal()
+			_, _ = fmt.Fprint(m.out, m.renderSummary())
+		})
 	}
 }
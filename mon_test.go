@@ -0,0 +1,169 @@
+package mon_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apollosoftwarexyz/mon"
+	"github.com/apollosoftwarexyz/mon/formatting"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestM_Tasks(t *testing.T) {
+	m := mon.New("test")
+	assert.Empty(t, m.Tasks())
+
+	task1 := m.AddTask().Name("1").Apply()
+	task2 := m.AddTask().Name("2").Apply()
+	assert.Equal(t, []mon.Task{task1, task2}, m.Tasks())
+
+	// Modifying the returned slice must not affect the monitor.
+	tasks := m.Tasks()
+	tasks[0] = task2
+	assert.Equal(t, []mon.Task{task1, task2}, m.Tasks())
+}
+
+func TestM_RemoveTask(t *testing.T) {
+	m := mon.New("test")
+	task1 := m.AddTask().Name("1").Apply()
+	task2 := m.AddTask().Name("2").Apply()
+	task3 := m.AddTask().Name("3").Apply()
+
+	m.RemoveTask(task2)
+	assert.Equal(t, []mon.Task{task1, task3}, m.Tasks())
+
+	// Removing a task that is not tracked by the monitor is a no-op.
+	m.RemoveTask(task2)
+	m.RemoveTask(createDefaultTask())
+	assert.Equal(t, []mon.Task{task1, task3}, m.Tasks())
+
+	// The removed task can still be updated.
+	task2.CompleteStep()
+	assert.True(t, task2.IsCompleted())
+}
+
+func TestM_prune(t *testing.T) {
+	m := mon.New("test")
+	running := m.AddTask().Apply()
+	completed := m.AddTask().Apply()
+	failed := m.AddTask().Name("failed").Apply()
+
+	completed.CompleteStep()
+	failed.Error(mockError)
+	mon.Expire(completed)
+	mon.Expire(failed)
+
+	// Expired tasks are removed from the monitor.
+	mon.Tick(m)
+	assert.Equal(t, []mon.Task{running}, m.Tasks())
+	assert.NotContains(t, mon.View(m), "failed")
+
+	// The failed task is still included in the summary.
+	assert.Contains(t, mon.Summary(m), "mock error")
+
+	// The removed tasks are still counted.
+	stats := m.Stats()
+	assert.Equal(t, 3, stats.Total)
+	assert.Equal(t, 1, stats.Running)
+	assert.Equal(t, 1, stats.Completed)
+	assert.Equal(t, 1, stats.Failed)
+}
+
+func TestM_Clear(t *testing.T) {
+	m := mon.New("test")
+	m.AddTask().Apply()
+	m.AddTask().Apply()
+	assert.Len(t, m.Tasks(), 2)
+
+	m.Clear()
+	assert.Empty(t, m.Tasks())
+
+	task := m.AddTask().Apply()
+	assert.Equal(t, []mon.Task{task}, m.Tasks())
+}
+
+func TestM_Task(t *testing.T) {
+	m := mon.New("test")
+	task1 := m.AddTask().ID("1").Apply()
+	task2 := m.AddTask().Apply()
+
+	task, ok := m.Task("1")
+	assert.True(t, ok)
+	assert.Equal(t, task1, task)
+
+	task, ok = m.Task(task2.GetID())
+	assert.True(t, ok)
+	assert.Equal(t, task2, task)
+
+	task, ok = m.Task("missing")
+	assert.False(t, ok)
+	assert.Nil(t, task)
+
+	// Removed tasks can no longer be retrieved.
+	m.RemoveTask(task1)
+	_, ok = m.Task("1")
+	assert.False(t, ok)
+}
+
+func TestM_FindTasks(t *testing.T) {
+	m := mon.New("test")
+	build := m.AddTask().Name("build").Category("ci").Apply()
+	test := m.AddTask().Name("test").Category("ci").Apply()
+	deploy := m.AddTask().Name("deploy").Category("cd").Apply()
+
+	assert.Equal(t, []mon.Task{build, test, deploy}, m.FindTasks(mon.TaskFilter{}))
+	assert.Equal(t, []mon.Task{test}, m.FindTasks(mon.TaskFilter{Name: "test"}))
+	assert.Equal(t, []mon.Task{build, test}, m.FindTasks(mon.TaskFilter{Category: "ci"}))
+	assert.Empty(t, m.FindTasks(mon.TaskFilter{Name: "deploy", Category: "ci"}))
+
+	build.CompleteStep()
+	deploy.Error(mockError)
+	assert.Equal(t, []mon.Task{build}, m.FindTasks(mon.TaskFilter{States: []mon.TaskState{mon.TaskStateCompleted}}))
+	assert.Equal(t, []mon.Task{test, deploy}, m.FindTasks(mon.TaskFilter{
+		States: []mon.TaskState{mon.TaskStateRunning, mon.TaskStateFailed},
+	}))
+}
+
+func TestM_DurationFormat(t *testing.T) {
+	m := mon.New("test")
+	m.AddTask().Name("task").TotalSteps(2).Apply().CompleteStep()
+	m.ShowOverallProgress()
+	assert.NotContains(t, mon.View(m), "PT")
+
+	m.DurationFormat(formatting.DurationISO8601)
+	view := mon.View(m)
+	assert.Regexp(t, `task \|\s+PT[0-9.]+S \|`, view)
+	assert.Regexp(t, `eta:\s+PT[0-9.]+S \|`, view)
+	assert.Regexp(t, `\| eta: PT[0-9.]+S`, view)
+}
+
+func TestM_DurationPrecision(t *testing.T) {
+	m := mon.New("test")
+	m.AddTask().Name("task").Apply()
+	assert.Regexp(t, `task \|\s+0\.\ds\s`, mon.View(m))
+
+	m.DurationPrecision(time.Millisecond)
+	assert.Regexp(t, `task \|\s+0\.\d{3}s\s`, mon.View(m))
+}
+
+// TestM_durationColumnWidth checks that the elapsed time column is padded to
+// the width of the longest duration of less than a day, so that it does not
+// change width as the elapsed time grows.
+func TestM_durationColumnWidth(t *testing.T) {
+	m := mon.New("test")
+	m.AddTask().Name("task").Apply()
+	assert.Regexp(t, `task \| {5}0\.\ds `, mon.View(m))
+
+	m.DurationFormat(formatting.DurationFixed)
+	assert.Regexp(t, `task \| 00:00:00\.\d `, mon.View(m))
+
+	// The column is as wide as the widest phrase of each unit (here, "about
+	// 59 minutes"), measured by display width rather than bytes.
+	formatting.SetLocale(&formatting.Locale{LessThanASecond: "<1s"})
+	t.Cleanup(func() { formatting.SetLocale(nil) })
+	m.DurationFormat(formatting.DurationHumanized)
+	assert.Regexp(t, `task \| {14}<1s `, mon.View(m))
+
+	formatting.SetLocale(&formatting.Locale{LessThanASecond: "<1s", About: "≈ %s"})
+	assert.Regexp(t, `task \| {10}<1s `, mon.View(m))
+}
@@ -1,27 +1,59 @@
 package mon
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/apollosoftwarexyz/mon/animations"
 	"github.com/apollosoftwarexyz/mon/formatting"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 )
 
 var (
 	boldStyle     = lipgloss.NewStyle().Bold(true)
+	dimStyle      = lipgloss.NewStyle().Faint(true)
 	completeStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("34"))
 	errorStyle    = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("160"))
+	warningStyle  = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("214"))
 )
 
 const (
-	completeIcon = "✓"
-	errorIcon    = "✖"
+	completeIcon  = "✓"
+	errorIcon     = "✖"
+	cancelledIcon = "⊘"
+	skippedIcon   = "↷"
+	blockedIcon   = "◌"
+
+	// logIndent is the indentation of task log lines beneath the task row.
+	logIndent = "    "
+)
+
+const (
+	// completedTaskRetention is how long a successfully completed task remains
+	// on the monitor before it is removed.
+	completedTaskRetention = 2 * time.Second
+
+	// failedTaskRetention is how long a failed (or cancelled) task remains on
+	// the monitor before it is removed. This is longer than
+	// completedTaskRetention to give the user a chance to read the error.
+	failedTaskRetention = 15 * time.Second
+
+	// maxSummaryTasks is the maximum number of failed tasks that are retained
+	// for the summary after they have been removed from the monitor.
+	maxSummaryTasks = 32
+
+	// maxExpiredTasks is the maximum number of expired tasks that an
+	// interactive monitor keeps (hidden) so that the user can show them again.
+	maxExpiredTasks = 100
 )
 
 type tickMsg struct {
@@ -31,12 +63,32 @@ type tickMsg struct {
 
 type notifyMsg struct{}
 
-type doneMsg struct{}
+type doneMsg struct {
+	cause error
+}
 
 type model struct {
-	prog              *tea.Program
-	exited            chan error
-	blockCancellation bool
+	prog    *tea.Program
+	out     io.Writer
+	running atomic.Bool
+	exited  chan error
+	cancel  context.CancelCauseFunc
+	cause   error
+
+	blockCancellation   bool
+	confirmCancellation bool
+	interrupts          int
+	blockedAt           time.Time
+	forceQuit           bool
+	exitOnForceQuit     bool
+
+	// programOptions are additional options for the bubbletea program (e.g.,
+	// to redirect its input and output in tests).
+	programOptions []tea.ProgramOption
+
+	scopesMutex       sync.Mutex
+	scopes            []*cancellationScope
+	deferredInterrupt error
 
 	spinnerAnim  *animations.A
 	ellipsisAnim *animations.A
@@ -48,8 +100,35 @@ type model struct {
 	notifyMutex sync.Mutex
 	tag         int
 
-	tasksMutex sync.RWMutex
-	tasks      []Task
+	// interactive controls (see interactive.go)
+	interactive   bool
+	height        int
+	showCompleted atomic.Bool
+	showDetail    bool
+	showHelp      bool
+	collapsed     map[string]bool
+	selection     row
+	hasSelection  bool
+	offset        int
+
+	tasksMutex   sync.RWMutex
+	tasks        []Task
+	failedTasks  []Task
+	retiredStats Stats
+	lastTaskID   atomic.Uint64
+
+	showOverallProgress bool
+	durationFormat      formatting.DurationFormat
+	durationPrecision   time.Duration
+
+	showWindowTitle     bool
+	showTaskbarProgress bool
+	windowTitle         string
+	taskbarProgress     string
+
+	sectionsMutex sync.Mutex
+	header        []Section
+	footer        []Section
 }
 
 func (m *model) tick(refreshRate time.Duration, tag int) tea.Cmd {
@@ -74,20 +153,100 @@ func (m *model) notify() {
 	}
 }
 
-func (m *model) notifyDone() {
+func (m *model) notifyDone(cause error) {
 	if m.prog == nil {
 		return
 	}
 
-	m.prog.Send(doneMsg{})
+	m.prog.Send(doneMsg{cause: cause})
+}
+
+// nextTaskID generates a unique ID for a task that was not given one.
+func (m *model) nextTaskID() string {
+	return "task-" + strconv.FormatUint(m.lastTaskID.Add(1), 10)
 }
 
 func (m *model) addTask(task Task) {
 	m.tasksMutex.Lock()
 	defer m.tasksMutex.Unlock()
+	m.pruneTasksLocked()
 	m.tasks = append(m.tasks, task)
 }
 
+func (m *model) removeTask(task Task) {
+	m.tasksMutex.Lock()
+	defer m.tasksMutex.Unlock()
+	m.tasks = slices.DeleteFunc(m.tasks, func(t Task) bool { return t == task })
+}
+
+// pruneTasks removes tasks that have outlived their retention window from the
+// monitor so that long-running monitors do not accumulate completed tasks.
+func (m *model) pruneTasks() {
+	m.tasksMutex.Lock()
+	defer m.tasksMutex.Unlock()
+	m.pruneTasksLocked()
+}
+
+// pruneTasksLocked is [model.pruneTasks] for callers that already hold the
+// tasksMutex.
+//
+// Failed tasks are retained separately (up to maxSummaryTasks) so that they
+// can be included in the summary printed when the monitor exits, and pruned
+// tasks continue to be counted by [M.Stats].
+func (m *model) pruneTasksLocked() {
+	// In interactive mode, the most recently added expired tasks (up to
+	// maxExpiredTasks) are kept, and hidden by [model.visibleTasks], so that
+	// the user can show them again.
+	var keep int
+	if m.interactive {
+		keep = maxExpiredTasks
+	}
+
+	excess := -keep
+	for _, t := range m.tasks {
+		if isExpired(t) {
+			excess++
+		}
+	}
+
+	if excess <= 0 {
+		return
+	}
+
+	m.tasks = slices.DeleteFunc(m.tasks, func(t Task) bool {
+		if excess <= 0 || !isExpired(t) {
+			return false
+		}
+
+		excess--
+		m.retiredStats.add(t)
+
+		if t.IsError() {
+			m.failedTasks = append(m.failedTasks, t)
+			if len(m.failedTasks) > maxSummaryTasks {
+				m.failedTasks = m.failedTasks[1:]
+			}
+		}
+
+		return true
+	})
+}
+
+// isExpired returns true if the task has been completed for longer than its
+// retention window.
+func isExpired(t Task) bool {
+	if !t.IsCompleted() {
+		return false
+	}
+
+	retention := completedTaskRetention
+	if t.GetState() != TaskStateCompleted {
+		retention = failedTaskRetention
+	}
+
+	return time.Since(t.GetCompletedAt()) > retention
+}
+
 func (m *model) Init() tea.Cmd {
 	// ensure we refresh at least once every 50ms.
 	return m.tick(50*time.Millisecond, 0)
@@ -103,22 +262,30 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		m.tag++
-		return m, m.tick(msg.refreshRate, m.tag)
+		m.pruneTasks()
+		return m, tea.Batch(m.tick(msg.refreshRate, m.tag), m.updateTerminal())
 	case notifyMsg:
 		return m, nil
 	case doneMsg:
 		m.done = true
+		m.cause = msg.cause
 		return m, tea.Quit
+	case signalMsg:
+		return m, m.handleSignal(msg.signal)
+	case tea.WindowSizeMsg:
+		m.height = msg.Height
+		return m, nil
 	case tea.KeyMsg:
+		if m.handleKey(msg) {
+			return m, nil
+		}
+
 		switch msg.String() {
 		case "ctrl+c":
-			if m.blockCancellation {
-				return m, nil
-			}
-
-			m.done = true
-			return m, tea.Quit
+			return m, m.handleInterrupt(ErrUserInterrupted)
 		}
+	case deferredInterruptMsg:
+		return m, m.handleInterrupt(msg.cause)
 	}
 
 	return m, nil
@@ -134,35 +301,54 @@ func (m *model) View() string {
 	t := time.Since(m.start)
 	spinner := m.spinnerAnim.Frame(t)
 
-	{
-		// Ensure the tasks are not currently being managed.
-		m.tasksMutex.RLock()
-		defer m.tasksMutex.RUnlock()
-
-		inProgressTasks := make([]Task, 0)
-
-		for _, t := range m.tasks {
-			if t.IsError() {
-				if time.Since(t.GetCompletedAt()).Seconds() > 15 {
-					continue
-				}
-			} else if t.IsCompleted() {
-				if time.Since(t.GetCompletedAt()).Seconds() > 2 {
-					continue
-				}
-			}
+	tasks := m.visibleTasks()
+	rows := m.rows(tasks)
 
-			inProgressTasks = append(inProgressTasks, t)
-		}
+	var detail string
+	if selected := m.selectedTask(rows); m.showDetail && selected != nil {
+		detail = m.renderDetail(selected)
+	}
 
-		for _, t := range inProgressTasks {
-			s.WriteString(m.renderTask(t, inProgressTasks, spinner))
-		}
+	var help string
+	if m.showHelp {
+		help = renderHelp()
+	}
+
+	cancellation := m.renderCancellation(tasks)
+	blockReasons := m.renderBlockReasons()
+	header := m.renderHeader()
+	footer := m.renderFooter()
+
+	// Fit the task list within the terminal, leaving room for the header, the
+	// detail pane, the footer (and the blank line above it), the cancellation
+	// banner, the reasons cancellation is blocked, the footer sections and the
+	// help footer.
+	lines := 0
+	if m.height > 0 {
+		lines = max(m.height-countLines(header, detail, cancellation, blockReasons, footer, help)-3, 1)
 	}
 
+	s.WriteString(header)
+	s.WriteString(m.renderRows(rows, tasks, spinner, lines))
+	s.WriteString(detail)
+
+	// The taskbar progress sequence is invisible, so it is written on the
+	// (otherwise blank) line above the caption, which the renderer only
+	// rewrites when it changes.
+	s.WriteString(m.taskbarProgress)
 	s.WriteRune('\n')
+	s.WriteString(cancellation)
+
+	var overallProgress string
+	if m.showOverallProgress {
+		overallProgress = m.renderOverallProgress(m.Stats())
+	}
 
-	s.WriteString(boldStyle.Render(fmt.Sprintf("%s (%0.1fs) %s%s\n", spinner, float64(t.Milliseconds())/1000, m.caption, m.ellipsisAnim.Frame(t))))
+	s.WriteString(boldStyle.Render(fmt.Sprintf("%s (%0.1fs) %s%s%s", spinner, float64(t.Milliseconds())/1000, m.caption, m.ellipsisAnim.Frame(t), overallProgress)))
+	s.WriteRune('\n')
+	s.WriteString(blockReasons)
+	s.WriteString(footer)
+	s.WriteString(help)
 
 	return s.String()
 }
@@ -180,8 +366,27 @@ func getLongestNameLength(tasks []Task) int {
 	return l
 }
 
+// getBlockingNames returns the names (or, if they have no name, the IDs) of the
+// dependencies of the task that have not yet completed.
+func getBlockingNames(t Task) []string {
+	names := make([]string, 0)
+	for _, dependency := range t.GetDependencies() {
+		if dependency.IsCompleted() {
+			continue
+		}
+
+		if name := dependency.GetName(); name != "" {
+			names = append(names, name)
+		} else {
+			names = append(names, dependency.GetID())
+		}
+	}
+
+	return names
+}
+
 func renderProgress(t Task) string {
-	return t.GetUnit().RenderProgress(t.GetCompleteSteps(), t.GetTotalSteps())
+	return formatting.RenderFloatProgress(t.GetUnit(), t.GetCompletedAmount(), t.GetTotalAmount())
 }
 
 func getLongestProgressLength(allTasks []Task) int {
@@ -196,21 +401,90 @@ func getLongestProgressLength(allTasks []Task) int {
 	return l
 }
 
-func (m *model) renderTask(t Task, allTasks []Task, spinner string) string {
+// columnWidths are the widths of the columns of the task list. They are
+// computed once per frame (see [model.columnWidths]) so that the columns are
+// aligned between rows.
+type columnWidths struct {
+	name                int
+	elapsed             int
+	progress            int
+	estimatedCompletion int
+}
+
+// columnWidths computes the widths of the columns of the task list for the
+// given tasks.
+func (m *model) columnWidths(allTasks []Task) columnWidths {
+	widths := columnWidths{
+		name:                getLongestNameLength(allTasks),
+		elapsed:             m.minDurationLength(),
+		progress:            getLongestProgressLength(allTasks),
+		estimatedCompletion: m.minDurationLength(),
+	}
+
+	for _, t := range allTasks {
+		widths.elapsed = max(widths.elapsed, ansi.StringWidth(m.formatDuration(t.GetElapsed())))
+		if eta, ok := t.GetEstimatedCompletion(); ok {
+			widths.estimatedCompletion = max(widths.estimatedCompletion, ansi.StringWidth(m.formatDuration(eta)))
+		}
+	}
+
+	return widths
+}
+
+// minDurationLength returns the minimum width of the elapsed and estimated
+// completion time columns: the width of the widest duration of less than a
+// day in the monitor's duration format and precision (e.g., "23:59:59" in the
+// clock format, or "about 59 minutes" in the humanized format). This keeps the
+// columns from changing width as durations grow.
+func (m *model) minDurationLength() int {
+	precision := m.durationPrecision
+	if precision <= 0 {
+		precision = formatting.DefaultDurationPrecision
+	}
+
+	// The widest duration of each unit is just before the next unit (or, for
+	// approximate formats, the largest whole number of the unit).
+	l := 0
+	for _, d := range []time.Duration{
+		0,
+		time.Minute - precision,
+		time.Hour - precision,
+		24*time.Hour - precision,
+		59 * time.Second,
+		59 * time.Minute,
+		23 * time.Hour,
+	} {
+		l = max(l, ansi.StringWidth(m.formatDuration(d)))
+	}
+
+	return l
+}
+
+// padLeft pads s with spaces on the left to the given display width.
+func padLeft(s string, width int) string {
+	return strings.Repeat(" ", max(0, width-ansi.StringWidth(s))) + s
+}
+
+func (m *model) renderTask(t Task, widths columnWidths, spinner string) string {
 	var s strings.Builder
 
 	icon := spinner
-	if t.IsCompleted() {
-		if t.IsError() {
-			icon = errorIcon
-		} else {
-			icon = completeStyle.Render(completeIcon)
-		}
+	switch t.GetState() {
+	case TaskStateFailed:
+		icon = errorIcon
+	case TaskStateCancelled:
+		icon = cancelledIcon
+	case TaskStateSkipped:
+		icon = skippedIcon
+	case TaskStateBlocked:
+		icon = blockedIcon
+	case TaskStateCompleted:
+		icon = completeStyle.Render(completeIcon)
 	}
 	s.WriteString(icon)
 	s.WriteRune(' ')
 	if name := t.GetName(); name != "" {
-		s.WriteString(fmt.Sprintf("%"+strconv.Itoa(getLongestNameLength(allTasks))+"s", name))
+		s.WriteString(fmt.Sprintf("%"+strconv.Itoa(widths.name)+"s", name))
 
 		if t.GetCaption() != "" {
 			s.WriteString(": ")
@@ -224,7 +498,8 @@ func (m *model) renderTask(t Task, allTasks []Task, spinner string) string {
 		s.WriteRune(' ')
 	}
 
-	s.WriteString(fmt.Sprintf("| %5s", formatting.Duration(t.GetElapsed())))
+	s.WriteString("| ")
+	s.WriteString(padLeft(m.formatDuration(t.GetElapsed()), widths.elapsed))
 	s.WriteString(" ")
 
 	if t.IsError() {
@@ -232,30 +507,106 @@ func (m *model) renderTask(t Task, allTasks []Task, spinner string) string {
 		s.WriteString(t.GetError().Error())
 	}
 
+	switch t.GetState() {
+	case TaskStateCancelled:
+		s.WriteString("| cancelled: ")
+		s.WriteString(t.GetError().Error())
+	case TaskStateSkipped:
+		s.WriteString("| skipped: ")
+		s.WriteString(t.GetError().Error())
+	case TaskStateBlocked:
+		s.WriteString("| waiting for: ")
+		s.WriteString(strings.Join(getBlockingNames(t), ", "))
+	}
+
 	if !t.IsIndeterminate() {
 		s.WriteString("| ")
-		s.WriteString(fmt.Sprintf("%"+strconv.Itoa(getLongestProgressLength(allTasks))+"s", renderProgress(t)))
+		s.WriteString(fmt.Sprintf("%"+strconv.Itoa(widths.progress)+"s", renderProgress(t)))
 		s.WriteString(" ")
 	}
 
 	estimatedCompletion, hasEstimatedCompletion := t.GetEstimatedCompletion()
 	if hasEstimatedCompletion {
 		s.WriteString("| ")
-		s.WriteString(fmt.Sprintf("eta: %5s |", formatting.Duration(estimatedCompletion)))
+		s.WriteString("eta: ")
+		s.WriteString(padLeft(m.formatDuration(estimatedCompletion), widths.estimatedCompletion))
+		s.WriteString(" |")
 	}
 
 	if !t.IsCompleted() {
+		// The average may be zero if many steps were completed at once, in
+		// which case the rate is unknown (rather than infinite).
 		averageTimePerStep, hasAverageTimePerStep := t.GetAverageTimePerStep()
-		if hasAverageTimePerStep {
+		if hasAverageTimePerStep && averageTimePerStep > 0 {
 			s.WriteRune(' ')
-			s.WriteString(fmt.Sprintf("%s/s", t.GetUnit().Render(uint64(1/averageTimePerStep.Seconds()))))
+			s.WriteString(formatting.RenderRate(t.GetUnit(), 1/averageTimePerStep.Seconds()))
 		}
 	}
 
-	if t.IsError() {
-		return errorStyle.Render(s.String()) + "\n"
+	var row string
+	switch t.GetState() {
+	case TaskStateFailed:
+		row = errorStyle.Render(s.String()) + "\n"
+	case TaskStateCancelled:
+		row = warningStyle.Render(s.String()) + "\n"
+	case TaskStateSkipped, TaskStateBlocked:
+		row = dimStyle.Render(s.String()) + "\n"
+	default:
+		row = s.String() + "\n"
+	}
+
+	if t.GetState() != TaskStateCompleted {
+		row += renderLogTail(t)
+	}
+
+	return row
+}
+
+// renderLogTail renders the last [Task.GetLogTail] lines of the task's log,
+// dimmed and indented to sit beneath the task's row.
+func renderLogTail(t Task) string {
+	n := t.GetLogTail()
+	if n < 1 {
+		return ""
+	}
+
+	lines := t.GetLog()
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+
+	var s strings.Builder
+	for _, line := range lines {
+		s.WriteString(dimStyle.Render(logIndent + line))
+		s.WriteRune('\n')
+	}
+
+	return s.String()
+}
+
+// renderSummary renders the failed tasks (including those that have already
+// been removed from the monitor), along with their full logs.
+//
+// If there are no failed tasks, an empty string is returned.
+func (m *model) renderSummary() string {
+	m.tasksMutex.RLock()
+	defer m.tasksMutex.RUnlock()
+
+	var s strings.Builder
+	for _, t := range slices.Concat(m.failedTasks, m.tasks) {
+		if !t.IsError() {
+			continue
+		}
+
+		s.WriteString(errorStyle.Render(fmt.Sprintf("%s %s%s", errorIcon, taskPrefix(t), t.GetError())))
+		s.WriteRune('\n')
+
+		for _, line := range t.GetLog() {
+			s.WriteString(logIndent)
+			s.WriteString(line)
+			s.WriteRune('\n')
+		}
 	}
 
-	s.WriteRune('\n')
 	return s.String()
 }
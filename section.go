@@ -0,0 +1,71 @@
+package mon
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Section of the monitor's display that is computed on every frame (see
+// [M.SetHeader] and [M.SetFooter]).
+//
+// The returned string may contain multiple lines. If it is empty, the section
+// is omitted from the display.
+//
+// Sections are called from the goroutine that renders the monitor, so they
+// should return quickly and must be safe to call concurrently with the rest of
+// the program.
+type Section func() string
+
+// StaticSection returns a [Section] that always displays the given string.
+func StaticSection(s string) Section {
+	return func() string { return s }
+}
+
+func (m *model) SetHeader(sections ...Section) {
+	m.sectionsMutex.Lock()
+	m.header = sections
+	m.sectionsMutex.Unlock()
+	m.notify()
+}
+
+func (m *model) SetFooter(sections ...Section) {
+	m.sectionsMutex.Lock()
+	m.footer = sections
+	m.sectionsMutex.Unlock()
+	m.notify()
+}
+
+// renderSections renders each of the given sections with the given style.
+func (m *model) renderSections(sections []Section, style lipgloss.Style) string {
+	var s strings.Builder
+	for _, section := range sections {
+		content := strings.TrimSuffix(section(), "\n")
+		if content == "" {
+			continue
+		}
+
+		// Style each line individually so that lines are not padded to the
+		// width of the longest line.
+		for _, line := range strings.Split(content, "\n") {
+			s.WriteString(style.Render(line))
+			s.WriteRune('\n')
+		}
+	}
+
+	return s.String()
+}
+
+// renderHeader renders the header sections of the monitor.
+func (m *model) renderHeader() string {
+	m.sectionsMutex.Lock()
+	defer m.sectionsMutex.Unlock()
+	return m.renderSections(m.header, boldStyle)
+}
+
+// renderFooter renders the footer sections of the monitor.
+func (m *model) renderFooter() string {
+	m.sectionsMutex.Lock()
+	defer m.sectionsMutex.Unlock()
+	return m.renderSections(m.footer, dimStyle)
+}
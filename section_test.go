@@ -0,0 +1,42 @@
+package mon_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/apollosoftwarexyz/mon"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestM_SetHeader(t *testing.T) {
+	m := mon.New("caption")
+	m.AddTask().Name("task").Apply()
+
+	m.SetHeader(mon.StaticSection("first\nsecond\n"), mon.StaticSection(""))
+	lines := viewLines(m)
+	assert.Equal(t, "first", lines[0])
+	assert.Equal(t, "second", lines[1])
+	assert.Contains(t, lines[2], "task")
+
+	m.SetHeader()
+	assert.Contains(t, viewLines(m)[0], "task")
+}
+
+func TestM_SetFooter(t *testing.T) {
+	m := mon.New("caption")
+
+	// Sections are computed on every frame.
+	frame := 0
+	m.SetFooter(func() string {
+		frame++
+		return "frame " + strconv.Itoa(frame)
+	})
+
+	lines := viewLines(m)
+	assert.Contains(t, lines[len(lines)-2], "caption")
+	assert.Equal(t, "frame 1", lines[len(lines)-1])
+	assert.Equal(t, "frame 2", viewLines(m)[len(lines)-1])
+
+	m.SetFooter()
+	assert.NotContains(t, mon.View(m), "frame")
+}
@@ -0,0 +1,209 @@
+package mon
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"strconv"
+)
+
+// Keys for the attributes that a handler created with [NewSlogHandler] uses
+// to update tasks.
+const (
+	// SlogTaskKey identifies the task a record applies to. The value may be a
+	// [Task], a task ID (see [Task.GetID]) or a task name.
+	SlogTaskKey = "task"
+
+	// SlogStepKey sets the number of completed steps of the task (see
+	// [Task.SetCompletedSteps]), or the completed amount if the value is
+	// fractional (see [Task.SetCompletedAmount]).
+	SlogStepKey = "step"
+
+	// SlogTotalKey sets the total number of steps of the task (see
+	// [Task.TotalSteps]), or the total amount if the value is fractional (see
+	// [Task.TotalAmount]).
+	SlogTotalKey = "total"
+)
+
+// NewSlogHandler creates a [slog.Handler] that drives the tasks of the given
+// monitor from log records.
+//
+// Records with a [SlogTaskKey] attribute that identifies a task tracked by the
+// monitor update that task:
+//   - [SlogTotalKey] and [SlogStepKey] attributes update the total and
+//     completed steps (or fractional amounts) of the task, respectively.
+//     These records are not printed.
+//   - Records at [slog.LevelError] or above fail the task with [Task.Error].
+//     If the record has an attribute with an error value, that error is used,
+//     otherwise the record's message is used.
+//
+// All other records are formatted with a [slog.TextHandler] configured with
+// opts (which may be nil) and printed above the live region of the monitor
+// with [M.Writer].
+//
+// Only attributes outside any group (see [slog.Handler.WithGroup]) are used to
+// update tasks.
+//
+// Records below the minimum level of opts (see [slog.HandlerOptions.Level],
+// which is [slog.LevelInfo] by default) are disabled, so they neither update
+// tasks nor are printed. To drive tasks from debug records, set the level
+// accordingly.
+func NewSlogHandler(m M, opts *slog.HandlerOptions) slog.Handler {
+	return &slogHandler{
+		m:     m,
+		inner: slog.NewTextHandler(m.Writer(), opts),
+	}
+}
+
+type slogHandler struct {
+	m     M
+	inner slog.Handler
+
+	// attrs are the attributes added with WithAttrs outside any group.
+	attrs   []slog.Attr
+	grouped bool
+}
+
+func (h *slogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.inner.Enabled(ctx, level)
+}
+
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	h2 := *h
+	h2.inner = h.inner.WithAttrs(attrs)
+	if !h.grouped {
+		h2.attrs = append(slices.Clip(h.attrs), attrs...)
+	}
+	return &h2
+}
+
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	h2 := *h
+	h2.inner = h.inner.WithGroup(name)
+	h2.grouped = true
+	return &h2
+}
+
+func (h *slogHandler) Handle(ctx context.Context, r slog.Record) error {
+	attrs := slices.Clone(h.attrs)
+	if !h.grouped {
+		r.Attrs(func(a slog.Attr) bool {
+			attrs = append(attrs, a)
+			return true
+		})
+	}
+
+	var (
+		task       Task
+		err        error
+		step       amount
+		total      amount
+		hasStep    bool
+		hasTotal   bool
+		controlled bool
+	)
+
+	for _, a := range attrs {
+		v := a.Value.Resolve()
+
+		switch a.Key {
+		case SlogTaskKey:
+			task = h.findTask(v)
+		case SlogStepKey:
+			step, hasStep = slogAmount(v)
+		case SlogTotalKey:
+			total, hasTotal = slogAmount(v)
+		default:
+			if e, ok := v.Any().(error); ok && v.Kind() == slog.KindAny {
+				err = e
+			}
+		}
+	}
+
+	if task != nil {
+		// Whole values are set exactly, as large numbers of steps cannot be
+		// represented exactly as a float.
+		if hasTotal {
+			if total.fraction == 0 {
+				task.TotalSteps(total.whole)
+			} else {
+				task.TotalAmount(total.float())
+			}
+			controlled = true
+		}
+
+		if hasStep {
+			if step.fraction == 0 {
+				task.SetCompletedSteps(step.whole)
+			} else {
+				task.SetCompletedAmount(step.float())
+			}
+			controlled = true
+		}
+
+		if r.Level >= slog.LevelError {
+			if err == nil {
+				err = errors.New(r.Message)
+			}
+			task.Error(err)
+
+			// Errors are always printed, so they remain visible once the
+			// task has been removed from the monitor.
+			controlled = false
+		}
+	}
+
+	if controlled {
+		return nil
+	}
+
+	return h.inner.Handle(ctx, r)
+}
+
+// findTask returns the task identified by the given value, or nil if there is
+// no such task.
+func (h *slogHandler) findTask(v slog.Value) Task {
+	if v.Kind() == slog.KindAny {
+		if task, ok := v.Any().(Task); ok {
+			return task
+		}
+	}
+
+	key := v.String()
+	if task, ok := h.m.Task(key); ok {
+		return task
+	}
+
+	if tasks := h.m.FindTasks(TaskFilter{Name: key}); len(tasks) > 0 {
+		return tasks[0]
+	}
+
+	return nil
+}
+
+// slogAmount converts a numeric (or numeric string) value to an [amount].
+// Negative values are clamped to zero.
+func slogAmount(v slog.Value) (amount, bool) {
+	switch v.Kind() {
+	case slog.KindUint64:
+		return steps(v.Uint64()), true
+	case slog.KindInt64:
+		return steps(uint64(max(v.Int64(), 0))), true
+	case slog.KindFloat64:
+		return amountOf(v.Float64()), true
+	case slog.KindString:
+		if n, err := strconv.ParseUint(v.String(), 10, 64); err == nil {
+			return steps(n), true
+		}
+
+		f, err := strconv.ParseFloat(v.String(), 64)
+		return amountOf(f), err == nil
+	default:
+		return amount{}, false
+	}
+}
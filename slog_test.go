@@ -0,0 +1,104 @@
+package mon_test
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/apollosoftwarexyz/mon"
+	"github.com/stretchr/testify/assert"
+)
+
+// createSlogLogger creates a logger that drives the given monitor, omitting
+// the time from printed records so that output is deterministic.
+func createSlogLogger(m mon.M) *slog.Logger {
+	return slog.New(mon.NewSlogHandler(m, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+}
+
+func TestSlogHandler_steps(t *testing.T) {
+	m, out := createLoggingMonitor()
+	task := m.AddTask().ID("download").Apply()
+	logger := createSlogLogger(m)
+
+	logger.Info("starting", "task", "download", "total", 10)
+	assert.Equal(t, uint64(10), task.GetTotalSteps())
+
+	logger.Info("progress", "task", "download", "step", uint64(4))
+	assert.Equal(t, uint64(4), task.GetCompleteSteps())
+
+	// Tasks can also be identified by name or by value.
+	task.SetName("fetch")
+	logger.Info("progress", "task", "fetch", "step", "5")
+	assert.Equal(t, uint64(5), task.GetCompleteSteps())
+
+	logger.With("task", task).Info("progress", "step", 10)
+	assert.True(t, task.IsCompleted())
+
+	// Records that update tasks are not printed.
+	assert.Empty(t, out.String())
+}
+
+func TestSlogHandler_amounts(t *testing.T) {
+	m, out := createLoggingMonitor()
+	task := m.AddTask().ID("download").Apply()
+	logger := createSlogLogger(m)
+
+	// Fractional values set amounts, rather than being truncated to steps.
+	logger.Info("starting", "task", "download", "total", 2.5)
+	assert.Equal(t, 2.5, task.GetTotalAmount())
+
+	logger.Info("progress", "task", "download", "step", 1.25)
+	assert.Equal(t, 1.25, task.GetCompletedAmount())
+
+	logger.Info("progress", "task", "download", "step", "2.25")
+	assert.Equal(t, 2.25, task.GetCompletedAmount())
+	assert.False(t, task.IsCompleted())
+
+	// Whole values are set exactly, even beyond the precision of a float.
+	logger.Info("starting", "task", "download", "total", uint64(1<<64-1), "step", "18446744073709551614")
+	assert.Equal(t, uint64(1<<64-1), task.GetTotalSteps())
+	assert.Equal(t, uint64(1<<64-2), task.GetCompleteSteps())
+
+	// Negative values are clamped to zero.
+	other := m.AddTask().ID("other").Apply()
+	logger.Info("starting", "task", "other", "total", -1.5)
+	assert.Zero(t, other.GetTotalAmount())
+
+	assert.Empty(t, out.String())
+}
+
+func TestSlogHandler_error(t *testing.T) {
+	m, out := createLoggingMonitor()
+	task := m.AddTask().ID("upload").Apply()
+	other := m.AddTask().ID("other").Apply()
+	logger := createSlogLogger(m)
+
+	logger.Error("upload failed", "task", "upload", "err", mockError)
+	assert.Equal(t, mockError, task.GetError())
+	assert.Equal(t, "level=ERROR msg=\"upload failed\" task=upload err=\"mock error\"\n", out.String())
+
+	logger.Error("other failed", "task", "other")
+	assert.EqualError(t, other.GetError(), "other failed")
+}
+
+func TestSlogHandler_print(t *testing.T) {
+	m, out := createLoggingMonitor()
+	task := m.AddTask().ID("build").TotalSteps(10).Apply()
+	logger := createSlogLogger(m)
+
+	logger.Info("hello", "n", 1)
+	logger.Info("unknown task", "task", "missing", "step", 1)
+	logger.WithGroup("g").Info("grouped", "task", "build", "step", 1)
+	logger.Debug("not enabled", "task", "build", "step", 1)
+
+	assert.Equal(t, uint64(0), task.GetCompleteSteps())
+	assert.Equal(t, "level=INFO msg=hello n=1\n"+
+		"level=INFO msg=\"unknown task\" task=missing step=1\n"+
+		"level=INFO msg=grouped g.task=build g.step=1\n", out.String())
+}
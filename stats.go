@@ -0,0 +1,138 @@
+package mon
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Stats aggregated across the tasks of a monitor (see [M.Stats]).
+//
+// Tasks that have been removed from the monitor automatically (once their
+// retention window has passed) are still counted, but tasks removed with
+// [M.RemoveTask] or [M.Clear] are not.
+type Stats struct {
+	// Total number of tasks.
+	Total int
+
+	// Running is the number of tasks that have not completed and are not
+	// blocked.
+	Running int
+
+	// Blocked is the number of tasks waiting for their dependencies.
+	Blocked int
+
+	// Completed is the number of tasks that completed successfully.
+	Completed int
+
+	// Failed is the number of tasks that completed with an error.
+	Failed int
+
+	// Cancelled is the number of tasks that were cancelled.
+	Cancelled int
+
+	// Skipped is the number of tasks that were skipped.
+	Skipped int
+
+	// Determinate is the number of tasks with a known number of total steps
+	// (see [Task.IsIndeterminate]).
+	Determinate int
+
+	// Progress is the mean progress (see [Task.GetProgress]) of the
+	// determinate tasks, from 0 to 1. As tasks may use different units, each
+	// task contributes equally regardless of its number of steps.
+	Progress float64
+
+	// EstimatedCompletion is the longest estimated completion time (see
+	// [Task.GetEstimatedCompletion]) of the running tasks. As tasks run
+	// concurrently, this is the estimated time until they have all completed.
+	EstimatedCompletion time.Duration
+
+	// HasEstimatedCompletion is true if any running task has an estimated
+	// completion time.
+	HasEstimatedCompletion bool
+
+	progressSum float64
+}
+
+// Done returns the number of tasks that have completed, whether successfully
+// or not.
+func (s Stats) Done() int {
+	return s.Completed + s.Failed + s.Cancelled + s.Skipped
+}
+
+// add the given task to the statistics.
+func (s *Stats) add(t Task) {
+	s.Total++
+
+	switch t.GetState() {
+	case TaskStateRunning:
+		s.Running++
+	case TaskStateBlocked:
+		s.Blocked++
+	case TaskStateCompleted:
+		s.Completed++
+	case TaskStateFailed:
+		s.Failed++
+	case TaskStateCancelled:
+		s.Cancelled++
+	case TaskStateSkipped:
+		s.Skipped++
+	}
+
+	if !t.IsIndeterminate() {
+		s.Determinate++
+		s.progressSum += t.GetProgress()
+		s.Progress = s.progressSum / float64(s.Determinate)
+	}
+
+	if eta, ok := t.GetEstimatedCompletion(); ok {
+		s.EstimatedCompletion = max(s.EstimatedCompletion, eta)
+		s.HasEstimatedCompletion = true
+	}
+}
+
+func (m *model) Stats() Stats {
+	m.tasksMutex.RLock()
+	defer m.tasksMutex.RUnlock()
+
+	stats := m.retiredStats
+	for _, t := range m.tasks {
+		stats.add(t)
+	}
+
+	return stats
+}
+
+func (m *model) ShowOverallProgress() M {
+	m.showOverallProgress = true
+	return m
+}
+
+// renderOverallProgress renders the overall progress of the monitor's tasks
+// for the footer.
+func (m *model) renderOverallProgress(stats Stats) string {
+	return m.renderOverallProgressPrecision(stats, m.durationPrecision)
+}
+
+// renderOverallProgressPrecision is [model.renderOverallProgress] with the
+// estimated completion time rendered to the given precision.
+func (m *model) renderOverallProgressPrecision(stats Stats, precision time.Duration) string {
+	var s strings.Builder
+
+	s.WriteString(fmt.Sprintf(" | %d/%d tasks", stats.Done(), stats.Total))
+
+	if stats.Determinate > 0 {
+		s.WriteString(fmt.Sprintf(" | %0.1f%%", stats.Progress*100))
+	}
+
+	if stats.HasEstimatedCompletion {
+		s.WriteString(fmt.Sprintf(" | eta: %s", m.durationFormat.FormatPrecision(stats.EstimatedCompletion, precision)))
+	}
+
+	if stats.Failed > 0 {
+		s.WriteString(fmt.Sprintf(" | %d failed", stats.Failed))
+	}
+
+	return s.String()
+}
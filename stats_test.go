@@ -0,0 +1,57 @@
+package mon_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apollosoftwarexyz/mon"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestM_Stats(t *testing.T) {
+	m := mon.New("test")
+	assert.Equal(t, 0, m.Stats().Total)
+
+	half := m.AddTask().TotalSteps(4).Apply()
+	half.CompleteSteps(2)
+	done := m.AddTask().TotalSteps(10).Apply()
+	done.CompleteSteps(10)
+	failed := m.AddTask().Apply()
+	failed.Error(mockError)
+	m.AddTask().DependsOn(half).Apply()
+	m.AddTask().Apply()
+
+	stats := m.Stats()
+	assert.Equal(t, 5, stats.Total)
+	assert.Equal(t, 2, stats.Running)
+	assert.Equal(t, 1, stats.Blocked)
+	assert.Equal(t, 1, stats.Completed)
+	assert.Equal(t, 1, stats.Failed)
+	assert.Equal(t, 2, stats.Done())
+
+	// Each determinate task contributes equally to the overall progress.
+	assert.Equal(t, 2, stats.Determinate)
+	assert.Equal(t, 0.75, stats.Progress)
+	assert.True(t, stats.HasEstimatedCompletion)
+	assert.Greater(t, stats.EstimatedCompletion, time.Duration(0))
+
+	// Removed tasks are no longer counted.
+	m.RemoveTask(failed)
+	assert.Equal(t, 4, m.Stats().Total)
+	assert.Equal(t, 0, m.Stats().Failed)
+
+	m.Clear()
+	assert.Equal(t, mon.Stats{}, m.Stats())
+}
+
+func TestM_ShowOverallProgress(t *testing.T) {
+	m := mon.New("test")
+	m.AddTask().TotalSteps(2).Apply().CompleteStep()
+	m.AddTask().Apply().Error(mockError)
+	assert.NotContains(t, mon.View(m), "tasks")
+
+	m.ShowOverallProgress()
+	view := mon.View(m)
+	assert.Contains(t, view, "| 1/2 tasks | 50.0% | eta: ")
+	assert.Contains(t, view, "| 1 failed")
+}
@@ -1,7 +1,13 @@
 package mon
 
 import (
-	"sync/atomic"
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/apollosoftwarexyz/mon/formatting"
@@ -9,6 +15,13 @@ import (
 
 // TaskBuilder for adding new [Task] references to a monitor ([M]).
 type TaskBuilder interface {
+	// ID sets the ID of the task, which can be used to retrieve the task from
+	// the monitor with [M.Task].
+	//
+	// IDs should be unique within a monitor. If this is not set, a unique ID is
+	// generated when the task is applied.
+	ID(id string) TaskBuilder
+
 	// Name sets the name of the task.
 	Name(name string) TaskBuilder
 
@@ -25,6 +38,37 @@ type TaskBuilder interface {
 	// of this task. If this is not set, then [Task.IsIndeterminate] is true.
 	TotalSteps(totalSteps uint64) TaskBuilder
 
+	// TotalAmount sets the (possibly fractional) total amount of the task, in
+	// its unit, that must be completed (e.g., 12.5 seconds of media). This is
+	// the fractional variant of [TaskBuilder.TotalSteps].
+	TotalAmount(total float64) TaskBuilder
+
+	// WithContext sets the parent of the task's context (see [Task.Context]).
+	// If this is not set, [context.Background] is used.
+	//
+	// If the parent context is cancelled before the task completes, the task
+	// is cancelled with the parent's cause.
+	WithContext(ctx context.Context) TaskBuilder
+
+	// DependsOn adds tasks that must complete before this task is ready to
+	// start. Until then, the task is displayed as blocked (see
+	// [TaskStateBlocked]) and [Task.WaitReady] blocks.
+	//
+	// If a dependency does not complete successfully, the task fails with a
+	// [*DependencyError] (or is skipped, see
+	// [TaskBuilder.SkipOnDependencyFailure]).
+	DependsOn(tasks ...Task) TaskBuilder
+
+	// SkipOnDependencyFailure marks the task as skipped (see
+	// [TaskStateSkipped]), rather than failed, if any of its dependencies do
+	// not complete successfully.
+	SkipOnDependencyFailure() TaskBuilder
+
+	// LogTail sets the number of lines from the end of the task's log (see
+	// [Task.Writer]) that are displayed beneath the task whilst it is running
+	// or if it fails. If this is not set, the log is not displayed.
+	LogTail(lines int) TaskBuilder
+
 	// Apply the task to the monitor that created the builder.
 	//
 	// This is the terminal step of the builder and returns the [Task] reference
@@ -33,12 +77,23 @@ type TaskBuilder interface {
 }
 
 type taskBuilder struct {
-	m          *model
-	name       string
-	caption    string
-	category   string
-	unit       formatting.Unit
-	totalSteps uint64
+	m        *model
+	id       string
+	name     string
+	caption  string
+	category string
+	unit     formatting.Unit
+	total    amount
+	ctx      context.Context
+	logTail  int
+
+	dependencies            []Task
+	skipOnDependencyFailure bool
+}
+
+func (b *taskBuilder) ID(id string) TaskBuilder {
+	b.id = id
+	return b
 }
 
 func (b *taskBuilder) Name(name string) TaskBuilder {
@@ -62,27 +117,76 @@ func (b *taskBuilder) Unit(unit formatting.Unit) TaskBuilder {
 }
 
 func (b *taskBuilder) TotalSteps(totalSteps uint64) TaskBuilder {
-	b.totalSteps = totalSteps
+	b.total = steps(totalSteps)
 	return b
 }
 
-func (b *taskBuilder) Apply() Task {
-	stepsTotal := &atomic.Uint64{}
-	stepsTotal.Store(b.totalSteps)
+func (b *taskBuilder) TotalAmount(total float64) TaskBuilder {
+	b.total = amountOf(total)
+	return b
+}
+
+func (b *taskBuilder) WithContext(ctx context.Context) TaskBuilder {
+	b.ctx = ctx
+	return b
+}
+
+func (b *taskBuilder) DependsOn(tasks ...Task) TaskBuilder {
+	b.dependencies = append(b.dependencies, tasks...)
+	return b
+}
+
+func (b *taskBuilder) SkipOnDependencyFailure() TaskBuilder {
+	b.skipOnDependencyFailure = true
+	return b
+}
+
+func (b *taskBuilder) LogTail(lines int) TaskBuilder {
+	b.logTail = lines
+	return b
+}
 
+func (b *taskBuilder) Apply() Task {
 	if b.unit == nil {
 		b.unit = &formatting.StepsUnit{}
 	}
 
+	if b.id == "" {
+		b.id = b.m.nextTaskID()
+	}
+
+	if b.ctx == nil {
+		b.ctx = context.Background()
+	}
+	ctx, cancelCtx := context.WithCancelCause(b.ctx)
+
 	task := &task{
-		notify:         b.m.notify,
-		name:           b.name,
-		caption:        b.caption,
-		category:       b.category,
-		unit:           b.unit,
-		startTime:      time.Now(),
-		stepsCompleted: &atomic.Uint64{},
-		stepsTotal:     stepsTotal,
+		notify:    b.m.notify,
+		logLine:   b.m.logLine,
+		id:        b.id,
+		name:      b.name,
+		caption:   b.caption,
+		category:  b.category,
+		unit:      b.unit,
+		startTime: time.Now(),
+		total:     b.total,
+		logTail:   b.logTail,
+		ctx:       ctx,
+		cancelCtx: cancelCtx,
+		done:      make(chan struct{}),
+		ready:     make(chan struct{}),
+
+		dependencies:            slices.Clone(b.dependencies),
+		skipOnDependencyFailure: b.skipOnDependencyFailure,
+	}
+	task.stopCancelWatch = context.AfterFunc(ctx, func() {
+		task.Cancel(context.Cause(ctx))
+	})
+
+	if len(task.dependencies) > 0 {
+		go task.watchDependencies()
+	} else {
+		close(task.ready)
 	}
 	b.m.addTask(task)
 	return task
@@ -90,6 +194,13 @@ func (b *taskBuilder) Apply() Task {
 
 // Task tracked by a monitor, [M].
 type Task interface {
+	// GetID of the task.
+	//
+	// This is either the ID given to [TaskBuilder.ID] or an ID generated by the
+	// monitor when the task was applied. It does not change for the lifetime of
+	// the task.
+	GetID() string
+
 	// GetName of the task.
 	GetName() string
 
@@ -108,16 +219,50 @@ type Task interface {
 	// SetCategory of the task.
 	SetCategory(category string)
 
+	// Log prints a message above the live region of the monitor, prefixed with
+	// the name of the task. Arguments are handled in the manner of
+	// [fmt.Print].
+	//
+	// See [M.Log] for details.
+	Log(a ...any)
+
+	// Logf prints a message above the live region of the monitor, prefixed
+	// with the name of the task. Arguments are handled in the manner of
+	// [fmt.Printf].
+	//
+	// See [M.Log] for details.
+	Logf(format string, a ...any)
+
+	// Writer returns an [io.Writer] that appends each line written to it to
+	// the task's log. Incomplete lines are buffered until a newline is
+	// written.
+	//
+	// The end of the log is displayed beneath the task (see
+	// [TaskBuilder.LogTail]) and, if the task fails, the full log is printed
+	// in the summary when the monitor exits.
+	Writer() io.Writer
+
+	// GetLog returns the lines written to the task's log with [Task.Writer].
+	GetLog() []string
+
+	// GetLogTail returns the number of lines from the end of the task's log
+	// that are displayed beneath the task.
+	GetLogTail() int
+
 	// GetUnit of the task. This is used to render progress based on steps.
 	GetUnit() formatting.Unit
 
+	// GetState of the task.
+	GetState() TaskState
+
 	// IsError returns true if Error has been called with a non-nil error.
 	IsError() bool
 
 	// GetError status of the task.
 	//
 	// If there is no error, GetError returns nil. Otherwise, a non-nil error is
-	// returned.
+	// returned. For cancelled tasks, this is the cause of the cancellation and
+	// for skipped tasks, this is the [*DependencyError].
 	GetError() error
 
 	// Error records that the task has failed with the given error.
@@ -127,6 +272,45 @@ type Task interface {
 	// complete.
 	Error(err error)
 
+	// Context returns the task's context, which is derived from the context
+	// given to [TaskBuilder.WithContext].
+	//
+	// The context is cancelled when the task is cancelled (see [Task.Cancel])
+	// and, to release its resources, when the task completes.
+	Context() context.Context
+
+	// Cancel the task with the given cause, cancelling its context (see
+	// [Task.Context]) and marking the task as complete. If cause is nil,
+	// [context.Canceled] is used.
+	//
+	// If the task IsCompleted, this function is a no-op.
+	Cancel(cause error)
+
+	// IsCancelled returns true if the task has been cancelled, either with
+	// [Task.Cancel] or because its parent context was cancelled.
+	IsCancelled() bool
+
+	// Done returns a channel that is closed once the task has completed (see
+	// [Task.IsCompleted]).
+	Done() <-chan struct{}
+
+	// GetDependencies returns the tasks that must complete before this task is
+	// ready to start (see [TaskBuilder.DependsOn]).
+	GetDependencies() []Task
+
+	// IsBlocked returns true if the task has not completed and is waiting for
+	// any of its dependencies to complete.
+	IsBlocked() bool
+
+	// WaitReady blocks until all of the task's dependencies have completed
+	// successfully, returning nil.
+	//
+	// If a dependency does not complete successfully, the [*DependencyError]
+	// is returned. If the task completes (for example, if it is cancelled)
+	// before it is ready, the task's error (see [Task.GetError]) is returned.
+	// If ctx is done first, the cause of ctx is returned.
+	WaitReady(ctx context.Context) error
+
 	// GetStartedAt returns the time that the task was started at.
 	GetStartedAt() time.Time
 
@@ -146,6 +330,9 @@ type Task interface {
 	// GetAverageTimePerStep computes a mean average of time per step using up
 	// to 256 discrete previous step times. If there are no completed steps,
 	// this function returns zero and false.
+	//
+	// For tasks with fractional amounts, this is the average time per whole
+	// unit of the amount.
 	GetAverageTimePerStep() (time.Duration, bool)
 
 	// GetEstimatedCompletion duration from now.
@@ -212,26 +399,176 @@ type Task interface {
 	//
 	// If the task IsDone, this function is a no-op.
 	TotalSteps(totalSteps uint64)
+
+	// GetCompletedAmount returns the (possibly fractional) amount of the task
+	// that has already been completed, in its unit. This is the fractional
+	// variant of [Task.GetCompleteSteps], which returns the whole part of the
+	// amount.
+	GetCompletedAmount() float64
+
+	// CompleteAmount adds the (possibly fractional) amount to the amount of
+	// the task that has already been completed, with the same semantics as
+	// [Task.CompleteSteps].
+	//
+	// If amount is not positive, this function is a no-op.
+	CompleteAmount(amount float64)
+
+	// SetCompletedAmount sets the (possibly fractional) amount of the task that
+	// has already been completed, with the same semantics as
+	// [Task.SetCompletedSteps].
+	SetCompletedAmount(amount float64)
+
+	// GetTotalAmount returns the (possibly fractional) amount of the task that
+	// must be completed. This is the fractional variant of
+	// [Task.GetTotalSteps], which returns the whole part of the amount.
+	GetTotalAmount() float64
+
+	// TotalAmount sets the (possibly fractional) amount of the task that must
+	// be completed, with the same semantics as [Task.TotalSteps].
+	TotalAmount(total float64)
+}
+
+// TaskState is the lifecycle state of a [Task].
+type TaskState int
+
+const (
+	// TaskStateRunning indicates that the task has not yet completed.
+	TaskStateRunning TaskState = iota
+
+	// TaskStateCompleted indicates that the task completed successfully.
+	TaskStateCompleted
+
+	// TaskStateFailed indicates that the task completed with an error (see
+	// [Task.Error]).
+	TaskStateFailed
+
+	// TaskStateCancelled indicates that the task was cancelled (see
+	// [Task.Cancel]).
+	TaskStateCancelled
+
+	// TaskStateBlocked indicates that the task has not yet completed and is
+	// waiting for its dependencies (see [TaskBuilder.DependsOn]).
+	TaskStateBlocked
+
+	// TaskStateSkipped indicates that the task was not run because one of its
+	// dependencies did not complete successfully (see
+	// [TaskBuilder.SkipOnDependencyFailure]).
+	TaskStateSkipped
+)
+
+func (s TaskState) String() string {
+	switch s {
+	case TaskStateRunning:
+		return "running"
+	case TaskStateCompleted:
+		return "completed"
+	case TaskStateFailed:
+		return "failed"
+	case TaskStateCancelled:
+		return "cancelled"
+	case TaskStateBlocked:
+		return "blocked"
+	case TaskStateSkipped:
+		return "skipped"
+	default:
+		return "TaskState(" + strconv.Itoa(int(s)) + ")"
+	}
+}
+
+// DependencyError is the error of a task that failed (or was skipped) because
+// one of its dependencies did not complete successfully (see
+// [TaskBuilder.DependsOn]).
+type DependencyError struct {
+	// Dependency that did not complete successfully.
+	Dependency Task
+}
+
+func (e *DependencyError) Error() string {
+	name := e.Dependency.GetName()
+	if name == "" {
+		name = e.Dependency.GetID()
+	}
+
+	return fmt.Sprintf("dependency %s %s", name, e.Dependency.GetState())
+}
+
+// Unwrap returns the error of the dependency, if any.
+func (e *DependencyError) Unwrap() error { return e.Dependency.GetError() }
+
+// TaskFilter selects tasks from a monitor with [M.FindTasks].
+//
+// Each field is optional; the zero value of a field matches any task. A task
+// must match every non-zero field to be selected.
+type TaskFilter struct {
+	// Name the task must have.
+	Name string
+
+	// Category the task must have.
+	Category string
+
+	// States lists the states the task may be in.
+	States []TaskState
+}
+
+// Match returns true if the given task is selected by the filter.
+func (f TaskFilter) Match(t Task) bool {
+	if f.Name != "" && t.GetName() != f.Name {
+		return false
+	}
+
+	if f.Category != "" && t.GetCategory() != f.Category {
+		return false
+	}
+
+	if len(f.States) > 0 && !slices.Contains(f.States, t.GetState()) {
+		return false
+	}
+
+	return true
 }
 
 type notifyFn func()
 
 type task struct {
-	notify         notifyFn
-	name           string
-	caption        string
-	category       string
-	unit           formatting.Unit
-	startTime      time.Time
-	endTime        time.Time
-	stepsCompleted *atomic.Uint64
-	stepsTotal     *atomic.Uint64
-	err            error
-
+	notify    notifyFn
+	logLine   logFn
+	id        string
+	name      string
+	caption   string
+	category  string
+	unit      formatting.Unit
+	startTime time.Time
+
+	logMutex sync.Mutex
+	log      []string
+	logTail  int
+
+	ctx             context.Context
+	cancelCtx       context.CancelCauseFunc
+	stopCancelWatch func() bool
+
+	// stateMutex guards the completion state of the task, which may be
+	// changed concurrently (e.g., when the parent context is cancelled).
+	stateMutex sync.RWMutex
+	state      TaskState
+	endTime    time.Time
+	err        error
+	done       chan struct{}
+
+	dependencies            []Task
+	skipOnDependencyFailure bool
+	ready                   chan struct{}
+
+	// progressMutex guards the progress of the task, which is measured in
+	// (possibly fractional) amounts of its unit.
+	progressMutex    sync.Mutex
+	completed        amount
+	total            amount
 	timeOfLastRecord time.Time
 	timePerStep      []time.Duration
 }
 
+func (t *task) GetID() string               { return t.id }
 func (t *task) GetName() string             { return t.name }
 func (t *task) SetName(name string)         { t.name = name }
 func (t *task) GetCaption() string          { return t.caption }
@@ -239,49 +576,217 @@ func (t *task) SetCaption(caption string)   { t.caption = caption }
 func (t *task) GetCategory() string         { return t.category }
 func (t *task) SetCategory(category string) { t.category = category }
 func (t *task) GetUnit() formatting.Unit    { return t.unit }
-func (t *task) IsError() bool               { return t.err != nil }
-func (t *task) GetError() error             { return t.err }
 
-func (t *task) Error(err error) {
-	if t.IsCompleted() {
-		return
+func (t *task) IsError() bool {
+	t.stateMutex.RLock()
+	defer t.stateMutex.RUnlock()
+	return t.state == TaskStateFailed
+}
+
+func (t *task) GetError() error {
+	t.stateMutex.RLock()
+	defer t.stateMutex.RUnlock()
+	return t.err
+}
+
+func (t *task) Log(a ...any) {
+	t.logLine(taskPrefix(t) + strings.TrimSuffix(fmt.Sprint(a...), "\n"))
+}
+
+func (t *task) Logf(format string, a ...any) {
+	t.logLine(taskPrefix(t) + strings.TrimSuffix(fmt.Sprintf(format, a...), "\n"))
+}
+
+func (t *task) Writer() io.Writer {
+	return &logWriter{log: t.appendLog}
+}
+
+func (t *task) appendLog(line string) {
+	t.logMutex.Lock()
+	t.log = append(t.log, line)
+	t.logMutex.Unlock()
+	t.notify()
+}
+
+func (t *task) GetLog() []string {
+	t.logMutex.Lock()
+	defer t.logMutex.Unlock()
+	return slices.Clone(t.log)
+}
+
+func (t *task) GetLogTail() int { return t.logTail }
+
+func (t *task) GetState() TaskState {
+	if t.IsBlocked() {
+		return TaskStateBlocked
 	}
 
+	t.stateMutex.RLock()
+	defer t.stateMutex.RUnlock()
+	return t.state
+}
+
+func (t *task) Error(err error) {
 	if err == nil {
 		return
 	}
 
+	t.complete(TaskStateFailed, err)
+}
+
+func (t *task) Context() context.Context { return t.ctx }
+
+func (t *task) IsCancelled() bool {
+	t.stateMutex.RLock()
+	defer t.stateMutex.RUnlock()
+	return t.state == TaskStateCancelled
+}
+
+func (t *task) Cancel(cause error) {
+	if cause == nil {
+		cause = context.Canceled
+	}
+
+	if t.complete(TaskStateCancelled, cause) {
+		t.notify()
+	}
+}
+
+// complete moves the task to the given completed state now, with the given
+// error (if any), and releases the task's context. If the task was already
+// completed, this function is a no-op and returns false.
+func (t *task) complete(state TaskState, err error) bool {
+	t.stateMutex.Lock()
+	if !t.endTime.IsZero() {
+		t.stateMutex.Unlock()
+		return false
+	}
+
+	t.state = state
 	t.endTime = time.Now()
 	t.err = err
+	t.stateMutex.Unlock()
+
+	close(t.done)
+	t.stopCancelWatch()
+	t.cancelCtx(err)
+	return true
+}
+
+func (t *task) Done() <-chan struct{}   { return t.done }
+func (t *task) GetDependencies() []Task { return slices.Clone(t.dependencies) }
+
+func (t *task) IsBlocked() bool {
+	if t.IsCompleted() {
+		return false
+	}
+
+	select {
+	case <-t.ready:
+		return false
+	default:
+		return true
+	}
+}
+
+func (t *task) WaitReady(ctx context.Context) error {
+	// Prefer reporting that the task is ready if it has also completed.
+	select {
+	case <-t.ready:
+		return nil
+	default:
+	}
+
+	select {
+	case <-t.ready:
+		return nil
+	case <-t.done:
+		return t.GetError()
+	case <-ctx.Done():
+		return context.Cause(ctx)
+	}
+}
+
+// watchDependencies waits for the task's dependencies to complete, marking the
+// task as ready if they all complete successfully or failing (or skipping) it
+// otherwise. This returns early if the task completes first.
+func (t *task) watchDependencies() {
+	completed := make(chan Task)
+	for _, dependency := range t.dependencies {
+		go func() {
+			select {
+			case <-dependency.Done():
+				select {
+				case completed <- dependency:
+				case <-t.done:
+				}
+			case <-t.done:
+			}
+		}()
+	}
+
+	for range t.dependencies {
+		var dependency Task
+		select {
+		case dependency = <-completed:
+		case <-t.done:
+			return
+		}
+
+		if dependency.GetState() == TaskStateCompleted {
+			continue
+		}
+
+		state := TaskStateFailed
+		if t.skipOnDependencyFailure {
+			state = TaskStateSkipped
+		}
+
+		if t.complete(state, &DependencyError{Dependency: dependency}) {
+			t.notify()
+		}
+		return
+	}
+
+	close(t.ready)
+	t.notify()
 }
 
-func (t *task) GetStartedAt() time.Time   { return t.startTime }
-func (t *task) GetCompletedAt() time.Time { return t.endTime }
+func (t *task) GetStartedAt() time.Time { return t.startTime }
+
+func (t *task) GetCompletedAt() time.Time {
+	t.stateMutex.RLock()
+	defer t.stateMutex.RUnlock()
+	return t.endTime
+}
 
 func (t *task) GetElapsed() time.Duration {
-	if !t.endTime.IsZero() {
-		return t.endTime.Sub(t.startTime)
+	if endTime := t.GetCompletedAt(); !endTime.IsZero() {
+		return endTime.Sub(t.startTime)
 	}
 
 	return time.Since(t.startTime)
 }
 
 func (t *task) GetProgress() float64 {
-	completed := t.stepsCompleted.Load()
-	total := t.stepsTotal.Load()
+	t.progressMutex.Lock()
+	defer t.progressMutex.Unlock()
 
-	if total == 0 {
-		if completed > 0 {
+	if t.total.isZero() {
+		if !t.completed.isZero() {
 			return 1
 		}
 
 		return 0
 	}
 
-	return float64(completed) / float64(total)
+	return t.completed.float() / t.total.float()
 }
 
 func (t *task) GetAverageTimePerStep() (time.Duration, bool) {
+	t.progressMutex.Lock()
+	defer t.progressMutex.Unlock()
+
 	if len(t.timePerStep) == 0 {
 		return 0, false
 	}
@@ -301,14 +806,29 @@ func (t *task) GetEstimatedCompletion() (time.Duration, bool) {
 		return 0, false
 	}
 
-	remainingSteps := t.GetTotalSteps() - t.GetCompleteSteps()
-	return time.Duration(remainingSteps) * avgTimePerStep, true
+	t.progressMutex.Lock()
+	remaining := t.total.sub(t.completed)
+	t.progressMutex.Unlock()
+
+	return time.Duration(remaining * float64(avgTimePerStep)), true
+}
+
+func (t *task) IsIndeterminate() bool {
+	t.progressMutex.Lock()
+	defer t.progressMutex.Unlock()
+	return t.total.isZero()
 }
 
-func (t *task) IsIndeterminate() bool { return t.stepsTotal.Load() == 0 }
-func (t *task) IsCompleted() bool     { return t.err != nil || !t.endTime.IsZero() }
+func (t *task) IsCompleted() bool {
+	t.stateMutex.RLock()
+	defer t.stateMutex.RUnlock()
+	return !t.endTime.IsZero()
+}
 
-func (t *task) recordTimePerSteps(n uint64) {
+// recordTimePerSteps records the average time taken for each unit of the
+// given amount, which was completed since the last record. The progress
+// mutex must be held.
+func (t *task) recordTimePerSteps(amount float64) {
 	var d time.Duration
 	if t.timeOfLastRecord.IsZero() {
 		d = time.Since(t.startTime)
@@ -316,13 +836,11 @@ func (t *task) recordTimePerSteps(n uint64) {
 		d = time.Since(t.timeOfLastRecord)
 	}
 
-	if n < 1 {
+	if amount <= 0 {
 		return
 	}
 
-	if n > 1 {
-		d /= time.Duration(n)
-	}
+	d = time.Duration(float64(d) / amount)
 
 	if len(t.timePerStep) >= 256 {
 		t.timeOfLastRecord = time.Now()
@@ -334,18 +852,17 @@ func (t *task) recordTimePerSteps(n uint64) {
 }
 
 func (t *task) checkCompleted() {
-	completed := t.stepsCompleted.Load()
-	total := t.stepsTotal.Load()
-
+	t.progressMutex.Lock()
 	var isDone bool
-	if total == 0 {
-		isDone = completed > 0
+	if t.total.isZero() {
+		isDone = !t.completed.isZero()
 	} else {
-		isDone = completed >= total
+		isDone = !t.completed.less(t.total)
 	}
+	t.progressMutex.Unlock()
 
 	if isDone {
-		t.endTime = time.Now()
+		t.complete(TaskStateCompleted, nil)
 	}
 }
 
@@ -354,67 +871,110 @@ func (t *task) CompleteStep() {
 }
 
 func (t *task) GetCompleteSteps() uint64 {
-	return t.stepsCompleted.Load()
+	t.progressMutex.Lock()
+	defer t.progressMutex.Unlock()
+	return t.completed.whole
 }
 
 func (t *task) CompleteSteps(completeSteps uint64) {
-	if t.IsCompleted() {
-		return
-	}
+	t.completeAmount(steps(completeSteps))
+}
+
+func (t *task) SetCompletedSteps(completeSteps uint64) {
+	t.setCompletedAmount(steps(completeSteps))
+}
+
+func (t *task) GetTotalSteps() uint64 {
+	t.progressMutex.Lock()
+	defer t.progressMutex.Unlock()
+	return t.total.whole
+}
+
+func (t *task) TotalSteps(totalSteps uint64) {
+	t.setTotalAmount(steps(totalSteps))
+}
+
+func (t *task) GetCompletedAmount() float64 {
+	t.progressMutex.Lock()
+	defer t.progressMutex.Unlock()
+	return t.completed.float()
+}
 
-	if completeSteps < 1 {
+func (t *task) CompleteAmount(completed float64) {
+	t.completeAmount(amountOf(completed))
+}
+
+func (t *task) SetCompletedAmount(completed float64) {
+	t.setCompletedAmount(amountOf(completed))
+}
+
+func (t *task) GetTotalAmount() float64 {
+	t.progressMutex.Lock()
+	defer t.progressMutex.Unlock()
+	return t.total.float()
+}
+
+func (t *task) TotalAmount(total float64) {
+	t.setTotalAmount(amountOf(total))
+}
+
+func (t *task) completeAmount(completed amount) {
+	if t.IsCompleted() || completed.isZero() {
 		return
 	}
 
-	completedSteps := t.stepsCompleted.Load()
-	if totalSteps := t.stepsTotal.Load(); totalSteps > 0 && completedSteps+completeSteps >= totalSteps {
-		t.stepsCompleted.Store(totalSteps)
-	} else {
-		t.stepsCompleted.Add(completeSteps)
+	t.progressMutex.Lock()
+	t.completed = t.completed.add(completed)
+
+	// If the total is exceeded, clamp the value.
+	if !t.total.isZero() && t.total.less(t.completed) {
+		t.completed = t.total
 	}
 
-	t.recordTimePerSteps(completeSteps)
+	t.recordTimePerSteps(completed.float())
+	t.progressMutex.Unlock()
+
 	t.checkCompleted()
 	t.notify()
 }
 
-func (t *task) SetCompletedSteps(completeSteps uint64) {
-	if t.IsCompleted() {
+func (t *task) setCompletedAmount(completed amount) {
+	if t.IsCompleted() || completed.isZero() {
 		return
 	}
 
-	if completeSteps == 0 {
-		return
-	}
+	t.progressMutex.Lock()
 
-	// If the number of steps already completed is greater than or equal to the
-	// new number of complete steps, do nothing.
-	if completedSteps := t.stepsCompleted.Load(); completedSteps >= completeSteps {
+	// If the amount already completed is greater than or equal to the new
+	// completed amount, do nothing.
+	if !t.completed.less(completed) {
+		t.progressMutex.Unlock()
 		return
 	}
 
-	// If the total number of steps is less than the given number of complete
-	// steps, clamp the value.
-	if totalSteps := t.stepsTotal.Load(); totalSteps < completeSteps {
-		completeSteps = totalSteps
+	// If the total is less than the given completed amount, clamp the value.
+	if t.total.less(completed) {
+		completed = t.total
 	}
 
-	previouslyCompleted := t.stepsCompleted.Swap(completeSteps)
-	t.recordTimePerSteps(completeSteps - previouslyCompleted)
+	previouslyCompleted := t.completed
+	t.completed = completed
+	t.recordTimePerSteps(completed.sub(previouslyCompleted))
+	t.progressMutex.Unlock()
+
 	t.checkCompleted()
 	t.notify()
 }
 
-func (t *task) GetTotalSteps() uint64 {
-	return t.stepsTotal.Load()
-}
-
-func (t *task) TotalSteps(totalSteps uint64) {
+func (t *task) setTotalAmount(total amount) {
 	if t.IsCompleted() {
 		return
 	}
 
-	t.stepsTotal.Store(totalSteps)
+	t.progressMutex.Lock()
+	t.total = total
+	t.progressMutex.Unlock()
+
 	t.checkCompleted()
 	t.notify()
 }
@@ -1,7 +1,9 @@
 package mon_test
 
 import (
+	"context"
 	"fmt"
+	"math"
 	"testing"
 	"time"
 
@@ -11,6 +13,7 @@ import (
 )
 
 const (
+	mockID         = "id"
 	notMockName    = "not name"
 	mockName       = "name"
 	mockCaption    = "caption"
@@ -35,6 +38,7 @@ func TestAddTask(t *testing.T) {
 	m := mon.New("test")
 	task := m.AddTask().
 		// test that builder methods overwrite former build methods
+		ID(mockID).
 		Name(notMockName).
 		Name(mockName).
 		Caption(mockCaption).
@@ -44,6 +48,7 @@ func TestAddTask(t *testing.T) {
 		Apply()
 
 	// test via the getters that the builder values have been correctly applied.
+	assert.Equal(t, task.GetID(), mockID)
 	assert.Equal(t, task.GetName(), mockName)
 	assert.Equal(t, task.GetCaption(), mockCaption)
 	assert.Equal(t, task.GetCategory(), mockCategory)
@@ -66,6 +71,30 @@ func testTaskGetterAndSetter[T comparable](t *testing.T, getter getterFunc[T], s
 	assert.Equal(t, getter(), mockValue)
 }
 
+// TestTaskID generates unique IDs when none are given.
+func TestTaskID(t *testing.T) {
+	m := mon.New("test")
+	task1 := m.AddTask().Apply()
+	task2 := m.AddTask().Apply()
+
+	assert.NotEmpty(t, task1.GetID())
+	assert.NotEmpty(t, task2.GetID())
+	assert.NotEqual(t, task1.GetID(), task2.GetID())
+}
+
+// TestTaskState follows the task through its lifecycle.
+func TestTaskState(t *testing.T) {
+	task := createDefaultTask()
+	assert.Equal(t, mon.TaskStateRunning, task.GetState())
+
+	task.CompleteStep()
+	assert.Equal(t, mon.TaskStateCompleted, task.GetState())
+
+	task = createDefaultTask()
+	task.Error(mockError)
+	assert.Equal(t, mon.TaskStateFailed, task.GetState())
+}
+
 // TestTaskName default, getter and setter work correctly.
 func TestTaskName(t *testing.T) {
 	task := createDefaultTask()
@@ -92,6 +121,39 @@ func TestTaskUnit(t *testing.T) {
 	assert.Equal(t, &formatting.StepsUnit{}, task.GetUnit())
 }
 
+// TestTaskUnit_render renders the progress of tasks with the fractional units.
+func TestTaskUnit_render(t *testing.T) {
+	m := mon.New("test")
+	m.AddTask().Name("percent").Unit(&formatting.PercentUnit{}).TotalSteps(1000).Apply().CompleteSteps(425)
+	m.AddTask().Name("ratio").Unit(&formatting.RatioUnit{}).TotalSteps(7).Apply().CompleteSteps(3)
+
+	view := mon.View(m)
+	assert.Contains(t, view, " 42.5% |")
+	assert.Contains(t, view, "| 3 of 7 |")
+}
+
+// TestTaskUnit_render_amount renders the progress of tasks with fractional
+// amounts.
+func TestTaskUnit_render_amount(t *testing.T) {
+	m := mon.New("test")
+	m.AddTask().Name("media").Unit(formatting.NewNounUnit("second", "seconds")).TotalAmount(60).Apply().CompleteAmount(12.5)
+
+	assert.Contains(t, mon.View(m), "| 12.5 / 60 seconds |")
+}
+
+// TestTaskUnit_render_rate does not render a rate if the average time per
+// step is zero (as the rate would be infinite).
+func TestTaskUnit_render_rate(t *testing.T) {
+	m := mon.New("test")
+	task := m.AddTask().Name("instant").TotalSteps(1 << 62).Apply()
+	task.CompleteSteps(1 << 61)
+
+	avg, ok := task.GetAverageTimePerStep()
+	assert.True(t, ok)
+	assert.Zero(t, avg)
+	assert.NotContains(t, mon.View(m), "Inf")
+}
+
 // TestTaskError default, getter and setter work correctly.
 func TestTaskError(t *testing.T) {
 	task := createDefaultTask()
@@ -109,6 +171,76 @@ func TestTaskError(t *testing.T) {
 	assert.True(t, task.IsError())
 }
 
+// TestTaskWriter appends complete lines to the task's log.
+func TestTaskWriter(t *testing.T) {
+	m := mon.New("test")
+	task := m.AddTask().LogTail(2).Apply()
+	assert.Equal(t, 2, task.GetLogTail())
+	assert.Empty(t, task.GetLog())
+
+	w := task.Writer()
+	_, _ = fmt.Fprint(w, "first\nsecond\nthi")
+	assert.Equal(t, []string{"first", "second"}, task.GetLog())
+
+	_, _ = fmt.Fprintln(w, "rd")
+	assert.Equal(t, []string{"first", "second", "third"}, task.GetLog())
+
+	// The log is retained once the task is complete.
+	task.Error(mockError)
+	assert.Equal(t, []string{"first", "second", "third"}, task.GetLog())
+}
+
+func TestTask_Cancel(t *testing.T) {
+	task := createDefaultTask()
+	assert.NoError(t, task.Context().Err())
+	assert.False(t, task.IsCancelled())
+
+	task.Cancel(mockError)
+	assert.True(t, task.IsCancelled())
+	assert.True(t, task.IsCompleted())
+	assert.False(t, task.IsError())
+	assert.Equal(t, mon.TaskStateCancelled, task.GetState())
+	assert.Equal(t, mockError, task.GetError())
+	assert.Equal(t, mockError, context.Cause(task.Context()))
+
+	// Once cancelled, the task cannot be failed or cancelled again.
+	task.Error(fmt.Errorf("other error"))
+	task.Cancel(nil)
+	assert.Equal(t, mon.TaskStateCancelled, task.GetState())
+	assert.Equal(t, mockError, task.GetError())
+
+	// A nil cause defaults to context.Canceled.
+	task = createDefaultTask()
+	task.Cancel(nil)
+	assert.Equal(t, context.Canceled, task.GetError())
+}
+
+func TestTask_WithContext(t *testing.T) {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	m := mon.New("test")
+	task := m.AddTask().WithContext(ctx).Apply()
+	assert.NoError(t, task.Context().Err())
+
+	// Cancelling the parent context cancels the task.
+	cancel(mockError)
+	assert.Eventually(t, task.IsCancelled, time.Second, time.Millisecond)
+	assert.Equal(t, mockError, task.GetError())
+}
+
+func TestTask_Context_completed(t *testing.T) {
+	// The context is released once the task completes, without cancelling
+	// the task.
+	task := createDefaultTask()
+	task.CompleteStep()
+	assert.Error(t, task.Context().Err())
+	assert.Equal(t, mon.TaskStateCompleted, task.GetState())
+
+	task = createDefaultTask()
+	task.Error(mockError)
+	assert.Equal(t, mockError, context.Cause(task.Context()))
+	assert.Equal(t, mon.TaskStateFailed, task.GetState())
+}
+
 func TestTask_GetStartedAt(t *testing.T) {
 	beforeCreation := time.Now()
 
@@ -308,3 +440,111 @@ func TestTask_SetCompletedSteps_clamp(t *testing.T) {
 	task.SetCompletedSteps(3)
 	assert.Equal(t, uint64(2), task.GetCompleteSteps())
 }
+
+// TestTask_steps_exact checks that whole steps are counted exactly across the
+// full range of uint64, despite fractional amounts being supported.
+func TestTask_steps_exact(t *testing.T) {
+	m := mon.New("test")
+	task := m.AddTask().Apply()
+	task.TotalSteps(math.MaxUint64)
+	assert.Equal(t, uint64(math.MaxUint64), task.GetTotalSteps())
+
+	task = m.AddTask().TotalSteps(1 << 60).Apply()
+	task.CompleteSteps(1 << 59)
+	task.CompleteStep()
+	assert.Equal(t, uint64(1<<59+1), task.GetCompleteSteps())
+
+	task.SetCompletedSteps(1<<60 - 1)
+	assert.Equal(t, uint64(1<<60-1), task.GetCompleteSteps())
+	assert.False(t, task.IsCompleted())
+
+	task.CompleteStep()
+	assert.True(t, task.IsCompleted())
+}
+
+func TestTask_TotalAmount(t *testing.T) {
+	m := mon.New("test")
+	task := m.AddTask().TotalAmount(2.5).Apply()
+	assert.False(t, task.IsIndeterminate())
+	assert.Equal(t, 2.5, task.GetTotalAmount())
+	assert.Equal(t, uint64(2), task.GetTotalSteps())
+
+	task.CompleteAmount(0.5)
+	assert.Equal(t, 0.2, task.GetProgress())
+	assert.False(t, task.IsCompleted())
+	assert.Equal(t, 0.5, task.GetCompletedAmount())
+	assert.Equal(t, uint64(0), task.GetCompleteSteps())
+
+	task.CompleteStep()
+	assert.Equal(t, 0.6, task.GetProgress())
+	assert.Equal(t, 1.5, task.GetCompletedAmount())
+	assert.Equal(t, uint64(1), task.GetCompleteSteps())
+
+	// Assert that changing the total amount changes the progress
+	// automatically.
+	task.TotalAmount(3)
+	assert.Equal(t, 0.5, task.GetProgress())
+	assert.False(t, task.IsCompleted())
+
+	task.SetCompletedAmount(3)
+	assert.Equal(t, 1.0, task.GetProgress())
+	assert.True(t, task.IsCompleted())
+}
+
+func TestTask_CompleteAmount_not_positive_is_no_op(t *testing.T) {
+	m := mon.New("test")
+	task := m.AddTask().TotalAmount(2).Apply()
+
+	task.CompleteAmount(0)
+	task.CompleteAmount(-1)
+	task.SetCompletedAmount(-1)
+	assert.Equal(t, 0.0, task.GetCompletedAmount())
+	assert.False(t, task.IsCompleted())
+}
+
+func TestTask_CompleteAmount_carry(t *testing.T) {
+	m := mon.New("test")
+	task := m.AddTask().TotalSteps(2).Apply()
+
+	// Fractional amounts add up to whole steps.
+	task.CompleteAmount(0.75)
+	task.CompleteAmount(0.25)
+	assert.Equal(t, uint64(1), task.GetCompleteSteps())
+	assert.Equal(t, 1.0, task.GetCompletedAmount())
+
+	task.CompleteAmount(0.5)
+	task.CompleteStep()
+	assert.Equal(t, 2.0, task.GetCompletedAmount())
+	assert.True(t, task.IsCompleted())
+}
+
+func TestTask_CompleteAmount_clamp(t *testing.T) {
+	m := mon.New("test")
+	task := m.AddTask().TotalAmount(1.5).Apply()
+
+	// The completed amount should be clamped to the total amount.
+	task.CompleteAmount(1.75)
+	assert.Equal(t, 1.5, task.GetCompletedAmount())
+	assert.True(t, task.IsCompleted())
+}
+
+func TestTask_GetEstimatedCompletion_amount(t *testing.T) {
+	m := mon.New("test")
+	task := m.AddTask().TotalAmount(10).Apply()
+
+	_, ok := task.GetEstimatedCompletion()
+	assert.False(t, ok)
+
+	time.Sleep(10 * time.Millisecond)
+	task.CompleteAmount(0.5)
+
+	// Half of a unit took (at least) 10ms, so the remaining 9.5 units should
+	// take (at least) 190ms.
+	avg, ok := task.GetAverageTimePerStep()
+	assert.True(t, ok)
+	assert.GreaterOrEqual(t, avg, 20*time.Millisecond)
+
+	eta, ok := task.GetEstimatedCompletion()
+	assert.True(t, ok)
+	assert.GreaterOrEqual(t, eta, 190*time.Millisecond)
+}
@@ -0,0 +1,97 @@
+package mon
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// windowTitlePrecision is the precision of the estimated completion time in
+// the window title. This is coarser than the monitor's precision so that the
+// title is not rewritten on every frame.
+const windowTitlePrecision = time.Second
+
+func (m *model) ShowWindowTitle() M {
+	m.showWindowTitle = true
+	return m
+}
+
+func (m *model) ShowTaskbarProgress() M {
+	m.showTaskbarProgress = true
+	return m
+}
+
+// renderWindowTitle renders the terminal window title from the caption and
+// the overall progress of the monitor's tasks.
+func (m *model) renderWindowTitle(stats Stats) string {
+	return m.caption + m.renderOverallProgressPrecision(stats, windowTitlePrecision)
+}
+
+// renderTaskbarProgress renders the OSC 9;4 progress sequence for the overall
+// progress of the monitor's tasks.
+func renderTaskbarProgress(stats Stats) string {
+	switch {
+	case stats.Failed > 0:
+		return ansi.SetErrorProgressBar(int(stats.Progress * 100))
+	case stats.Determinate == 0:
+		if stats.Total == stats.Done() {
+			return ansi.ResetProgressBar
+		}
+
+		return ansi.SetIndeterminateProgressBar
+	default:
+		return ansi.SetProgressBar(int(stats.Progress * 100))
+	}
+}
+
+// updateTerminal updates the window title and taskbar progress (if enabled).
+//
+// Both are written through the bubbletea program, so that they are not
+// interleaved with its frames: the window title with the returned command
+// (only when it has changed), and the taskbar progress as part of the view
+// (see [model.View]).
+func (m *model) updateTerminal() tea.Cmd {
+	if !m.showWindowTitle && !m.showTaskbarProgress {
+		return nil
+	}
+
+	stats := m.Stats()
+
+	if m.showTaskbarProgress {
+		m.taskbarProgress = renderTaskbarProgress(stats)
+	}
+
+	if !m.showWindowTitle {
+		return nil
+	}
+
+	title := m.renderWindowTitle(stats)
+	if title == m.windowTitle {
+		return nil
+	}
+
+	m.windowTitle = title
+	return tea.SetWindowTitle(title)
+}
+
+// resetTerminal clears the window title and taskbar progress if they were
+// set by the monitor. This writes to the terminal directly, so it must only be
+// called once the bubbletea program has exited.
+func (m *model) resetTerminal() {
+	var s string
+	if m.windowTitle != "" {
+		s += ansi.SetWindowTitle("")
+	}
+
+	if m.taskbarProgress != "" {
+		s += ansi.ResetProgressBar
+	}
+
+	m.windowTitle = ""
+	m.taskbarProgress = ""
+	if s != "" {
+		_, _ = fmt.Fprint(m.out, s)
+	}
+}
@@ -0,0 +1,68 @@
+package mon_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/apollosoftwarexyz/mon"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestM_ShowWindowTitle(t *testing.T) {
+	var out bytes.Buffer
+	m := mon.New("caption").ShowWindowTitle()
+	mon.SetOutput(m, &out)
+
+	task := m.AddTask().TotalSteps(2).Apply()
+	cmd := mon.UpdateTerminal(m)
+	if assert.NotNil(t, cmd) {
+		assert.Equal(t, tea.SetWindowTitle("caption | 0/1 tasks | 0.0%")(), cmd())
+	}
+
+	// The title is only set when it changes.
+	assert.Nil(t, mon.UpdateTerminal(m))
+
+	task.CompleteSteps(2)
+	cmd = mon.UpdateTerminal(m)
+	if assert.NotNil(t, cmd) {
+		assert.Equal(t, tea.SetWindowTitle("caption | 1/1 tasks | 100.0%")(), cmd())
+	}
+
+	// The title is never written to the output directly whilst the monitor
+	// is shown.
+	assert.Empty(t, out.String())
+
+	mon.ResetTerminal(m)
+	assert.Equal(t, "\x1b]2;\x07", out.String())
+}
+
+func TestM_ShowTaskbarProgress(t *testing.T) {
+	var out bytes.Buffer
+	m := mon.New("caption").ShowTaskbarProgress()
+	mon.SetOutput(m, &out)
+
+	// Nothing is written to the terminal if it was never updated.
+	mon.ResetTerminal(m)
+	assert.Empty(t, out.String())
+
+	indeterminate := m.AddTask().Apply()
+	assert.Nil(t, mon.UpdateTerminal(m))
+	assert.Contains(t, mon.View(m), "\x1b]9;4;3\x07")
+
+	task := m.AddTask().TotalSteps(4).Apply()
+	task.CompleteSteps(1)
+	mon.UpdateTerminal(m)
+	assert.Contains(t, mon.View(m), "\x1b]9;4;1;25\x07")
+
+	indeterminate.Error(mockError)
+	mon.UpdateTerminal(m)
+	assert.Contains(t, mon.View(m), "\x1b]9;4;2;25\x07")
+
+	// The progress is only written as part of the view.
+	assert.Empty(t, out.String())
+
+	mon.ResetTerminal(m)
+	assert.Equal(t, "\x1b]9;4;0\x07", out.String())
+	assert.NotContains(t, mon.View(m), "\x1b]9;4")
+}